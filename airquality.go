@@ -0,0 +1,163 @@
+package weathersync
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// airQualityVariables is the list of Open-Meteo air quality variables
+// requested for AirQualityData.
+const airQualityVariables = "pm2_5,pm10,ozone,nitrogen_dioxide,sulphur_dioxide,carbon_monoxide,european_aqi,us_aqi"
+
+// pollenVariables lists the pollen variables of the air quality API. They
+// are only available in Europe during the pollen season.
+var pollenVariables = []string{
+	"alder_pollen", "birch_pollen", "grass_pollen",
+	"mugwort_pollen", "olive_pollen", "ragweed_pollen",
+}
+
+// AirQualityData contains current air quality information for a location.
+// Concentrations are in μg/m³. Values the API did not report are NaN, since
+// zero is a valid reading.
+type AirQualityData struct {
+	// Location is the geographic location this data applies to
+	Location Location
+
+	// PM25 is the concentration of particulate matter below 2.5 μm
+	PM25 float64
+
+	// PM10 is the concentration of particulate matter below 10 μm
+	PM10 float64
+
+	// Ozone is the ozone (O₃) concentration
+	Ozone float64
+
+	// NitrogenDioxide is the nitrogen dioxide (NO₂) concentration
+	NitrogenDioxide float64
+
+	// SulphurDioxide is the sulphur dioxide (SO₂) concentration
+	SulphurDioxide float64
+
+	// CarbonMonoxide is the carbon monoxide (CO) concentration
+	CarbonMonoxide float64
+
+	// EuropeanAQI is the European Air Quality Index (0-20 good, above 100
+	// extremely poor)
+	EuropeanAQI float64
+
+	// USAQI is the United States Air Quality Index (0-50 good, above 300
+	// hazardous)
+	USAQI float64
+
+	// Pollen maps pollen types (e.g. "birch_pollen") to their concentration
+	// in grains/m³. Only types reported for the location are included; it is
+	// nil outside Europe and the pollen season.
+	Pollen map[string]float64
+
+	// ObservationTime is the time the values refer to (see WithTimezone)
+	ObservationTime time.Time
+
+	// FetchDuration is the time it took to fetch this data
+	FetchDuration time.Duration
+
+	// Timestamp is when this data was fetched
+	Timestamp time.Time
+
+	// Attempts is the number of HTTP requests made to fetch this data,
+	// including retries (see WithRetry)
+	Attempts int
+
+	// Error is set if fetching data for this location failed
+	// (only used by FetchAirQualityMultiple)
+	Error error
+}
+
+// WithAirQualityURL sets a custom air quality API URL.
+// Default is "https://air-quality-api.open-meteo.com".
+func WithAirQualityURL(url string) Option {
+	return func(c *Client) {
+		c.airQualityURL = url
+	}
+}
+
+// FetchAirQuality retrieves current air quality for a single location from
+// the Open-Meteo air quality API, using the client's HTTP client, timeout,
+// retry policy and rate limit.
+func (c *Client) FetchAirQuality(ctx context.Context, location Location) (*AirQualityData, error) {
+	location = c.autoName(ctx, location)
+
+	url := fmt.Sprintf("%s/v1/air-quality?latitude=%f&longitude=%f&current=%s,%s%s",
+		c.airQualityURL, location.Latitude, location.Longitude,
+		airQualityVariables, strings.Join(pollenVariables, ","), timezoneParam(c.timezone))
+
+	start := time.Now()
+
+	// The response has the same shape as a current weather response
+	var apiResp currentResponse
+	attempts, err := c.fetchJSON(ctx, url, &apiResp)
+	if err != nil {
+		return nil, err
+	}
+
+	observed, err := apiResp.observationTime()
+	if err != nil {
+		return nil, err
+	}
+
+	apiResp.fillTimezone(&location, c.timezone)
+
+	data := &AirQualityData{
+		Location:        location,
+		PM25:            apiResp.float("pm2_5"),
+		PM10:            apiResp.float("pm10"),
+		Ozone:           apiResp.float("ozone"),
+		NitrogenDioxide: apiResp.float("nitrogen_dioxide"),
+		SulphurDioxide:  apiResp.float("sulphur_dioxide"),
+		CarbonMonoxide:  apiResp.float("carbon_monoxide"),
+		EuropeanAQI:     apiResp.float("european_aqi"),
+		USAQI:           apiResp.float("us_aqi"),
+		ObservationTime: observed,
+		FetchDuration:   time.Since(start),
+		Timestamp:       time.Now(),
+		Attempts:        attempts,
+	}
+
+	for _, name := range pollenVariables {
+		if v, ok := apiResp.value(name); ok {
+			if data.Pollen == nil {
+				data.Pollen = make(map[string]float64)
+			}
+			data.Pollen[name] = v
+		}
+	}
+
+	return data, nil
+}
+
+// FetchAirQualityMultiple retrieves air quality for multiple locations
+// concurrently, like FetchMultiple. Results are in the order of locations;
+// failures are reported in the Error field of each result.
+func (c *Client) FetchAirQualityMultiple(ctx context.Context, locations []Location) []AirQualityData {
+	results := make([]AirQualityData, len(locations))
+
+	c.forEach(ctx, len(locations), func(i int) {
+		data, err := c.FetchAirQuality(ctx, locations[i])
+		if err != nil {
+			results[i] = AirQualityData{
+				Location: c.autoName(ctx, locations[i]),
+				Error:    err,
+			}
+			return
+		}
+		results[i] = *data
+	}, func(i int) {
+		results[i] = AirQualityData{
+			Location: c.autoName(ctx, locations[i]),
+			Error:    ctx.Err(),
+		}
+	})
+
+	return results
+}
@@ -0,0 +1,109 @@
+package weathersync
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestFetchAirQuality tests parsing of an air quality response
+func TestFetchAirQuality(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/air-quality" {
+			t.Errorf("Expected path /v1/air-quality, got %s", r.URL.Path)
+		}
+		if current := r.URL.Query().Get("current"); !strings.Contains(current, "pm2_5") || !strings.Contains(current, "birch_pollen") {
+			t.Errorf("Unexpected current parameter %q", current)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"timezone": "Europe/Berlin",
+			"utc_offset_seconds": 7200,
+			"current": {
+				"time": "2024-05-01T14:00",
+				"pm2_5": 8.4,
+				"pm10": 12.1,
+				"ozone": 96.0,
+				"nitrogen_dioxide": 14.3,
+				"carbon_monoxide": 180.0,
+				"european_aqi": 38,
+				"us_aqi": 45,
+				"birch_pollen": 52.5,
+				"grass_pollen": 3.1,
+				"olive_pollen": null
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAirQualityURL(server.URL))
+
+	data, err := client.FetchAirQuality(context.Background(), Location{Name: "Berlin"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if data.PM25 != 8.4 || data.PM10 != 12.1 || data.Ozone != 96 || data.CarbonMonoxide != 180 {
+		t.Errorf("Unexpected concentrations %+v", data)
+	}
+	if data.EuropeanAQI != 38 || data.USAQI != 45 {
+		t.Errorf("Expected AQI 38/45, got %f/%f", data.EuropeanAQI, data.USAQI)
+	}
+
+	// Not reported, which is different from clean air
+	if !math.IsNaN(data.SulphurDioxide) {
+		t.Errorf("Expected NaN for missing sulphur dioxide, got %f", data.SulphurDioxide)
+	}
+
+	if len(data.Pollen) != 2 || data.Pollen["birch_pollen"] != 52.5 {
+		t.Errorf("Unexpected pollen %v", data.Pollen)
+	}
+
+	if data.ObservationTime.IsZero() || data.Location.Timezone != "Europe/Berlin" {
+		t.Errorf("Expected observation time and timezone, got %v/%q", data.ObservationTime, data.Location.Timezone)
+	}
+}
+
+// TestFetchAirQualityMultiple tests result order and per-location errors
+func TestFetchAirQualityMultiple(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("latitude") == "99.000000" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": true, "reason": "Latitude must be in range of -90 to 90°."}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"current": {"pm2_5": 20.0}}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAirQualityURL(server.URL), WithMaxConcurrency(2))
+
+	results := client.FetchAirQualityMultiple(context.Background(), []Location{
+		{Name: "A", Latitude: 10},
+		{Name: "Invalid", Latitude: 99},
+		{Name: "C", Latitude: 30},
+	})
+
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+
+	for i, want := range []string{"A", "Invalid", "C"} {
+		if results[i].Location.Name != want {
+			t.Errorf("Result %d: expected %s, got %s", i, want, results[i].Location.Name)
+		}
+	}
+
+	if results[0].Error != nil || results[0].PM25 != 20 {
+		t.Errorf("Unexpected first result %+v", results[0])
+	}
+	if results[1].Error == nil {
+		t.Error("Expected error for invalid location")
+	}
+}
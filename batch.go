@@ -0,0 +1,171 @@
+package weathersync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WithBatchSize makes FetchMultiple group up to n locations into a single
+// API request using Open-Meteo's comma-separated coordinate lists.
+// It has no effect with other providers.
+// Values of n below 2 disable batching (one request per location, the default).
+func WithBatchSize(n int) Option {
+	return func(c *Client) {
+		c.batchSize = n
+	}
+}
+
+// fetchBatched implements FetchMultiple when batching is enabled for the
+// Open-Meteo provider om.
+// Locations with and without a known elevation are batched separately, since
+// a batch shares one elevation= parameter (see elevationParam).
+// Batches are fetched concurrently, subject to WithMaxConcurrency. If the API
+// rejects a batch because one of its coordinates is invalid
+// (ErrInvalidLocation), its locations are retried one request at a time so
+// each gets its own result. Other batch errors, such as an unknown variable,
+// are recorded on every location of the batch.
+func (c *Client) fetchBatched(ctx context.Context, om *OpenMeteo, locations []Location, q fetchQuery) []WeatherData {
+	results := make([]WeatherData, len(locations))
+
+	// Each batch holds indexes into locations
+	var batches [][]int
+	for _, known := range []bool{false, true} {
+		var group []int
+		for i, location := range locations {
+			if (location.Elevation != 0) == known {
+				group = append(group, i)
+			}
+		}
+		for len(group) > 0 {
+			n := c.batchSize
+			if n > len(group) {
+				n = len(group)
+			}
+			batches = append(batches, group[:n])
+			group = group[n:]
+		}
+	}
+
+	c.forEach(ctx, len(batches), func(j int) {
+		indexes := batches[j]
+		batch := make([]Location, len(indexes))
+		for k, i := range indexes {
+			batch[k] = locations[i]
+		}
+
+		data, err := c.fetchBatch(ctx, om, batch, q)
+		if err != nil && len(batch) > 1 && errors.Is(err, ErrInvalidLocation) {
+			for _, i := range indexes {
+				results[i] = c.fetchSingle(ctx, om, locations[i], q)
+			}
+			return
+		}
+		for k, i := range indexes {
+			if err != nil {
+				results[i] = WeatherData{
+					Location: locations[i],
+					Error:    err,
+				}
+				continue
+			}
+			results[i] = data[k]
+		}
+	}, func(j int) {
+		for _, i := range batches[j] {
+			results[i] = WeatherData{
+				Location: locations[i],
+				Error:    ctx.Err(),
+			}
+		}
+	})
+
+	return results
+}
+
+// fetchSingle retrieves current weather for one location of a rejected
+// batch, recording any failure in the Error field.
+func (c *Client) fetchSingle(ctx context.Context, om *OpenMeteo, location Location, q fetchQuery) WeatherData {
+	data, err := c.fetchBatch(ctx, om, []Location{location}, q)
+	if err != nil {
+		return WeatherData{
+			Location: location,
+			Error:    err,
+		}
+	}
+	return data[0]
+}
+
+// fetchBatch retrieves current weather for all locations in one request to om.
+// Locations found in the client's cache are not requested again.
+// The returned slice has the same length and order as batch.
+func (c *Client) fetchBatch(ctx context.Context, om *OpenMeteo, batch []Location, q fetchQuery) ([]WeatherData, error) {
+	start := time.Now()
+	params := om.Name() + "?" + q.params()
+
+	results := make([]WeatherData, len(batch))
+	var missing []int
+	for i, location := range batch {
+		if data, ok := c.cachedWeather(weatherCacheKey(location, params), location, start); ok {
+			results[i] = *data
+			continue
+		}
+		missing = append(missing, i)
+	}
+
+	if len(missing) == 0 {
+		return results, nil
+	}
+
+	lats := make([]string, len(missing))
+	lons := make([]string, len(missing))
+	requested := make([]Location, len(missing))
+	for j, i := range missing {
+		lats[j] = fmt.Sprintf("%f", batch[i].Latitude)
+		lons[j] = fmt.Sprintf("%f", batch[i].Longitude)
+		requested[j] = batch[i]
+	}
+
+	url := fmt.Sprintf("%s/v1/forecast?latitude=%s&longitude=%s&%s%s",
+		om.baseURL(), strings.Join(lats, ","), strings.Join(lons, ","), q.params(), elevationParam(requested...))
+
+	var raw json.RawMessage
+	attempts, err := c.fetchJSON(ctx, url, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	// A single coordinate yields an object, several yield an array
+	var responses []currentResponse
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(raw, &responses); err != nil {
+			return nil, &decodeError{err}
+		}
+	} else {
+		responses = make([]currentResponse, 1)
+		if err := json.Unmarshal(raw, &responses[0]); err != nil {
+			return nil, &decodeError{err}
+		}
+	}
+
+	if len(responses) != len(missing) {
+		return nil, &decodeError{fmt.Errorf("batch response has %d results, want %d", len(responses), len(missing))}
+	}
+
+	for j, i := range missing {
+		data, err := responses[j].weatherData(batch[i], start, q)
+		if err != nil {
+			return nil, err
+		}
+		data.Attempts = attempts
+		data.Source = om.Name()
+		c.storeWeather(weatherCacheKey(batch[i], params), data)
+		results[i] = *data
+	}
+
+	return results, nil
+}
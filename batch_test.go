@@ -0,0 +1,182 @@
+package weathersync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// TestFetchMultipleBatched tests that locations are grouped into batch requests
+// and results are mapped back to the input order
+func TestFetchMultipleBatched(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+
+		lats := strings.Split(r.URL.Query().Get("latitude"), ",")
+		w.Header().Set("Content-Type", "application/json")
+
+		if len(lats) == 1 {
+			w.Write([]byte(`{"current": {"temperature_2m": 30}}`))
+			return
+		}
+
+		// Echo the latitude back as the temperature
+		parts := make([]string, len(lats))
+		for i, lat := range lats {
+			parts[i] = `{"current": {"temperature_2m": ` + lat + `}}`
+		}
+		w.Write([]byte("[" + strings.Join(parts, ",") + "]"))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL), WithBatchSize(2))
+
+	locations := []Location{
+		{Name: "A", Latitude: 10, Longitude: 0},
+		{Name: "B", Latitude: 20, Longitude: 0},
+		{Name: "C", Latitude: 30, Longitude: 0},
+	}
+
+	results := client.FetchMultiple(context.Background(), locations)
+
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("Expected 2 API calls, got %d", got)
+	}
+
+	for i, result := range results {
+		if result.Error != nil {
+			t.Fatalf("Result %d: unexpected error %v", i, result.Error)
+		}
+		if result.Location.Name != locations[i].Name {
+			t.Errorf("Result %d: expected location %s, got %s", i, locations[i].Name, result.Location.Name)
+		}
+		if result.Temperature != locations[i].Latitude {
+			t.Errorf("Result %d: expected temperature %f, got %f", i, locations[i].Latitude, result.Temperature)
+		}
+	}
+}
+
+// TestFetchMultipleBatchedMismatch tests that a short batch response is
+// reported as an error on every location of the batch
+func TestFetchMultipleBatchedMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"current": {"temperature_2m": 1}}]`))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL), WithBatchSize(5))
+
+	locations := []Location{
+		{Name: "A", Latitude: 1, Longitude: 1},
+		{Name: "B", Latitude: 2, Longitude: 2},
+	}
+
+	results := client.FetchMultiple(context.Background(), locations)
+
+	for i, result := range results {
+		if result.Error == nil {
+			t.Errorf("Result %d: expected error, got nil", i)
+		}
+		if result.Location.Name != locations[i].Name {
+			t.Errorf("Result %d: expected location %s, got %s", i, locations[i].Name, result.Location.Name)
+		}
+	}
+}
+
+// TestFetchMultipleBatchedInvalidLocation tests that a batch rejected because
+// of one invalid coordinate is retried per location
+func TestFetchMultipleBatchedInvalidLocation(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+
+		lats := strings.Split(r.URL.Query().Get("latitude"), ",")
+		w.Header().Set("Content-Type", "application/json")
+
+		for _, lat := range lats {
+			if lat == "95.000000" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error": true, "reason": "Latitude must be in range of -90 to 90°. Given: 95.0."}`))
+				return
+			}
+		}
+
+		if len(lats) != 1 {
+			t.Errorf("Unexpected valid batch of %d locations", len(lats))
+		}
+		w.Write([]byte(`{"current": {"temperature_2m": ` + lats[0] + `}}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL), WithBatchSize(3))
+
+	locations := []Location{
+		{Name: "A", Latitude: 10, Longitude: 0},
+		{Name: "Bad", Latitude: 95, Longitude: 0},
+		{Name: "C", Latitude: 30, Longitude: 0},
+	}
+
+	results := client.FetchMultiple(context.Background(), locations)
+
+	// One rejected batch request, then one request per location
+	if got := atomic.LoadInt32(&calls); got != 4 {
+		t.Errorf("Expected 4 API calls, got %d", got)
+	}
+
+	if !errors.Is(results[1].Error, ErrInvalidLocation) {
+		t.Errorf("Expected ErrInvalidLocation for the invalid location, got %v", results[1].Error)
+	}
+
+	for _, i := range []int{0, 2} {
+		if results[i].Error != nil {
+			t.Errorf("Result %d: unexpected error %v", i, results[i].Error)
+			continue
+		}
+		if results[i].Temperature != locations[i].Latitude {
+			t.Errorf("Result %d: expected temperature %f, got %f", i, locations[i].Latitude, results[i].Temperature)
+		}
+	}
+}
+
+// TestFetchMultipleBatchedBadRequest tests that a 400 unrelated to the
+// coordinates is reported once per batch instead of retried per location
+func TestFetchMultipleBatchedBadRequest(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": true, "reason": "Cannot initialize WeatherVariable from invalid String value bogus"}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL), WithBatchSize(3), WithVariables("bogus"))
+
+	locations := make([]Location, 6)
+	for i := range locations {
+		locations[i] = Location{Name: fmt.Sprintf("L%d", i), Latitude: float64(i), Longitude: 0}
+	}
+
+	results := client.FetchMultiple(context.Background(), locations)
+
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("Expected 2 API calls, got %d", got)
+	}
+
+	for i, result := range results {
+		var apiErr *APIError
+		if !errors.As(result.Error, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+			t.Errorf("Result %d: expected API error 400, got %v", i, result.Error)
+		}
+	}
+}
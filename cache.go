@@ -0,0 +1,188 @@
+package weathersync
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Cache stores weather results between calls. Implementations must be safe
+// for concurrent use and are responsible for expiring stale entries.
+type Cache interface {
+	// Get returns the data stored under key, if present and not expired.
+	Get(key string) (WeatherData, bool)
+
+	// Set stores data under key.
+	Set(key string, data WeatherData)
+}
+
+// WithCache makes FetchWeather and FetchMultiple consult cache before
+// calling the API. Cache hits are returned with Cached set to true and the
+// Timestamp of the original fetch. Default is no cache.
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// cacheCoordinatePrecision is the number of decimals coordinates are rounded
+// to when building cache keys (about 1 km), so nearby lookups share an entry.
+const cacheCoordinatePrecision = 2
+
+// weatherCacheKey builds the cache key for location and the request
+// parameters that shape the response. A known elevation is part of the key
+// since it changes the downscaled values.
+func weatherCacheKey(location Location, params string) string {
+	key := fmt.Sprintf("%.*f,%.*f",
+		cacheCoordinatePrecision, location.Latitude,
+		cacheCoordinatePrecision, location.Longitude)
+	if location.Elevation != 0 {
+		key += fmt.Sprintf("@%.0fm", location.Elevation)
+	}
+	return key + "?" + params
+}
+
+// cachedWeather looks up key in the client's cache. On a hit it returns a
+// copy of the cached data adjusted for the requesting location.
+func (c *Client) cachedWeather(key string, location Location, start time.Time) (*WeatherData, bool) {
+	if c.cache == nil {
+		return nil, false
+	}
+
+	cached, ok := c.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+
+	data := cached.clone()
+	if location.Timezone == "" {
+		location.Timezone = data.Location.Timezone
+	}
+	data.Location = location
+	data.Cached = true
+	data.Attempts = 0
+	data.FetchDuration = time.Since(start)
+	return &data, true
+}
+
+// storeWeather saves a successful result in the client's cache.
+func (c *Client) storeWeather(key string, data *WeatherData) {
+	if c.cache == nil || data.Error != nil {
+		return
+	}
+	c.cache.Set(key, data.clone())
+}
+
+// clone returns a copy of d that shares no maps with it, so callers can
+// modify their result without affecting cached or shared data.
+func (d *WeatherData) clone() WeatherData {
+	data := *d
+	if d.Spread != nil {
+		data.Spread = make(map[string]float64, len(d.Spread))
+		for name, v := range d.Spread {
+			data.Spread[name] = v
+		}
+	}
+	if d.Extra != nil {
+		data.Extra = make(map[string]float64, len(d.Extra))
+		for name, v := range d.Extra {
+			data.Extra[name] = v
+		}
+	}
+	if d.Models != nil {
+		data.Models = make(map[string]WeatherData, len(d.Models))
+		for model, m := range d.Models {
+			data.Models[model] = m.clone()
+		}
+	}
+	return data
+}
+
+// MemoryCache is an in-memory Cache with a fixed capacity and a time-to-live.
+// When full, the least recently used entry is evicted.
+type MemoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	items    map[string]*list.Element
+	order    *list.List // front is most recently used
+}
+
+// memoryCacheEntry is the value stored in MemoryCache.order.
+type memoryCacheEntry struct {
+	key     string
+	data    WeatherData
+	expires time.Time
+}
+
+// NewMemoryCache creates a MemoryCache holding up to capacity entries,
+// each valid for ttl after it was stored.
+func NewMemoryCache(capacity int, ttl time.Duration) *MemoryCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &MemoryCache{
+		capacity: capacity,
+		ttl:      ttl,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get implements Cache.
+func (m *MemoryCache) Get(key string) (WeatherData, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.items[key]
+	if !ok {
+		return WeatherData{}, false
+	}
+
+	entry := elem.Value.(*memoryCacheEntry)
+	if time.Now().After(entry.expires) {
+		m.order.Remove(elem)
+		delete(m.items, key)
+		return WeatherData{}, false
+	}
+
+	m.order.MoveToFront(elem)
+	return entry.data, true
+}
+
+// Set implements Cache.
+func (m *MemoryCache) Set(key string, data WeatherData) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expires := time.Now().Add(m.ttl)
+
+	if elem, ok := m.items[key]; ok {
+		entry := elem.Value.(*memoryCacheEntry)
+		entry.data = data
+		entry.expires = expires
+		m.order.MoveToFront(elem)
+		return
+	}
+
+	m.items[key] = m.order.PushFront(&memoryCacheEntry{
+		key:     key,
+		data:    data,
+		expires: expires,
+	})
+
+	for m.order.Len() > m.capacity {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.items, oldest.Value.(*memoryCacheEntry).key)
+	}
+}
+
+// Len returns the number of entries currently held, including expired
+// entries that have not been evicted yet.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
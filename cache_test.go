@@ -0,0 +1,154 @@
+package weathersync
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestFetchWeatherCached tests that repeated fetches within the TTL are
+// served from the cache
+func TestFetchWeatherCached(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"current": {"temperature_2m": 15.3}}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL), WithCache(NewMemoryCache(10, time.Minute)))
+
+	first, err := client.FetchWeather(context.Background(), Location{Name: "Berlin", Latitude: 52.52, Longitude: 13.41})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if first.Cached {
+		t.Error("Expected first fetch not to be cached")
+	}
+
+	// Slightly different coordinates round to the same key
+	second, err := client.FetchWeather(context.Background(), Location{Name: "Berlin Mitte", Latitude: 52.521, Longitude: 13.409})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Expected 1 API call, got %d", got)
+	}
+
+	if !second.Cached {
+		t.Error("Expected second fetch to be cached")
+	}
+
+	if !second.Timestamp.Equal(first.Timestamp) {
+		t.Errorf("Expected original timestamp %v, got %v", first.Timestamp, second.Timestamp)
+	}
+
+	if second.Location.Name != "Berlin Mitte" || second.Temperature != 15.3 {
+		t.Errorf("Unexpected cached data %+v", second)
+	}
+}
+
+// TestFetchWeatherCachedCopy tests that modifying a result does not change
+// the cached data
+func TestFetchWeatherCachedCopy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"current": {"temperature_2m": 15.3, "uv_index": 4.5}}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL), WithCache(NewMemoryCache(10, time.Minute)),
+		WithVariables(Temperature, UVIndex))
+	location := Location{Name: "Berlin", Latitude: 52.52, Longitude: 13.41}
+
+	first, err := client.FetchWeather(context.Background(), location)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	first.Extra["uv_index"] = 0
+
+	second, err := client.FetchWeather(context.Background(), location)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !second.Cached || second.Extra["uv_index"] != 4.5 {
+		t.Errorf("Expected cached uv_index 4.5, got %v (cached %v)", second.Extra["uv_index"], second.Cached)
+	}
+	second.Extra["uv_index"] = 1
+
+	third, err := client.FetchWeather(context.Background(), location)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if third.Extra["uv_index"] != 4.5 {
+		t.Errorf("Expected cached uv_index 4.5, got %v", third.Extra["uv_index"])
+	}
+}
+
+// TestFetchMultipleBatchedCached tests that batched requests skip cached locations
+func TestFetchMultipleBatchedCached(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"current": {"temperature_2m": 15.3}}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL), WithBatchSize(10), WithCache(NewMemoryCache(10, time.Minute)))
+
+	locations := []Location{{Name: "A", Latitude: 1}}
+	client.FetchMultiple(context.Background(), locations)
+	results := client.FetchMultiple(context.Background(), locations)
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Expected 1 API call, got %d", got)
+	}
+	if !results[0].Cached {
+		t.Error("Expected cached result")
+	}
+}
+
+// TestMemoryCacheExpiry tests that entries expire after the TTL
+func TestMemoryCacheExpiry(t *testing.T) {
+	cache := NewMemoryCache(10, 10*time.Millisecond)
+	cache.Set("k", WeatherData{Temperature: 1})
+
+	if _, ok := cache.Get("k"); !ok {
+		t.Fatal("Expected hit before TTL")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := cache.Get("k"); ok {
+		t.Error("Expected miss after TTL")
+	}
+}
+
+// TestMemoryCacheEviction tests least-recently-used eviction
+func TestMemoryCacheEviction(t *testing.T) {
+	cache := NewMemoryCache(2, time.Minute)
+	cache.Set("a", WeatherData{Temperature: 1})
+	cache.Set("b", WeatherData{Temperature: 2})
+
+	// Touch "a" so "b" becomes the least recently used
+	cache.Get("a")
+	cache.Set("c", WeatherData{Temperature: 3})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Expected b to be evicted")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Error("Expected a to be kept")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", cache.Len())
+	}
+}
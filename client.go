@@ -22,16 +22,34 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"sync"
 	"time"
 )
 
 // Client is the main entry point for the weathersync library.
 // It provides methods to fetch weather data for single or multiple locations.
 type Client struct {
-	apiURL     string
-	httpClient *http.Client
-	timeout    time.Duration
+	apiURL        string
+	archiveURL    string
+	geocodingURL  string
+	airQualityURL string
+	marineURL     string
+	floodURL      string
+	httpClient    *http.Client
+	timeout       time.Duration
+	batchSize     int
+
+	maxConcurrency int
+	retry          retryPolicy
+	limiter        *rateLimiter
+	cache          Cache
+	flights        flightGroup
+	provider       Provider
+	models         []string
+	variables      []Variable
+	units          Units
+	timezone       string
+
+	reverseGeocoder ReverseGeocoder
 }
 
 // Option is a function that configures a Client.
@@ -60,19 +78,44 @@ func WithAPIURL(url string) Option {
 	}
 }
 
+// WithArchiveURL sets a custom historical weather (archive) API URL.
+// Default is "https://archive-api.open-meteo.com".
+func WithArchiveURL(url string) Option {
+	return func(c *Client) {
+		c.archiveURL = url
+	}
+}
+
 // New creates a new weathersync Client with the given options.
 // If no options are provided, sensible defaults are used.
 func New(opts ...Option) *Client {
 	c := &Client{
-		apiURL:     "https://api.open-meteo.com",
-		httpClient: &http.Client{},
-		timeout:    10 * time.Second,
+		apiURL:        "https://api.open-meteo.com",
+		archiveURL:    "https://archive-api.open-meteo.com",
+		geocodingURL:  "https://geocoding-api.open-meteo.com",
+		airQualityURL: "https://air-quality-api.open-meteo.com",
+		marineURL:     "https://marine-api.open-meteo.com",
+		floodURL:      "https://flood-api.open-meteo.com",
+		httpClient:    &http.Client{},
+		timeout:       10 * time.Second,
+		units:         Metric,
+		timezone:      "auto",
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
 
+	if c.provider == nil {
+		c.provider = NewOpenMeteo("")
+	}
+	if c.reverseGeocoder == nil {
+		c.reverseGeocoder = NewOfflineGeocoder(0)
+	}
+	if b, ok := c.provider.(binder); ok {
+		b.bind(c)
+	}
+
 	// Apply timeout to HTTP client
 	c.httpClient.Timeout = c.timeout
 
@@ -80,105 +123,139 @@ func New(opts ...Option) *Client {
 }
 
 // FetchWeather retrieves current weather data for a single location.
-// It respects the context for cancellation and timeouts.
+// It respects the context for cancellation and timeouts, and serves
+// repeated requests from the cache configured with WithCache. Concurrent
+// calls for the same location share a single API request. The data comes
+// from the client's Provider (Open-Meteo unless set with WithProvider).
+// Locations without a Name are named with NameLocation.
 //
 // Parameters:
 //   - ctx: context for cancellation and timeout control
 //   - location: the geographic location to fetch weather for
+//   - opts: optional per-call settings such as Models or Variables
 //
 // Returns:
 //   - *WeatherData containing temperature and metadata
 //   - error if the request fails or data is invalid
-func (c *Client) FetchWeather(ctx context.Context, location Location) (*WeatherData, error) {
-	url := fmt.Sprintf("%s/v1/forecast?latitude=%f&longitude=%f&current=temperature_2m,apparent_temperature,relative_humidity_2m,precipitation,weather_code,wind_speed_10m,wind_direction_10m,wind_gusts_10m,cloud_cover,visibility,pressure_msl",
-		c.apiURL, location.Latitude, location.Longitude)
-
+func (c *Client) FetchWeather(ctx context.Context, location Location, opts ...FetchOption) (*WeatherData, error) {
 	start := time.Now()
 
+	location = c.autoName(ctx, location)
+	q := c.newQuery(opts)
+	params := c.provider.Name() + "?" + q.params()
+	key := weatherCacheKey(location, params)
+	if data, ok := c.cachedWeather(key, location, start); ok {
+		return data, nil
+	}
+
+	flightKey := fmt.Sprintf("%q@%f,%f,%g?%s", location.Name, location.Latitude, location.Longitude, location.Elevation, params)
+	data, err := c.flights.do(ctx, flightKey, func(ctx context.Context) (*WeatherData, error) {
+		data, err := c.provider.Current(withQuery(ctx, q), location)
+		if err != nil {
+			return nil, err
+		}
+		data.convertUnits(q.units)
+		if data.Source == "" {
+			data.Source = c.provider.Name()
+		}
+
+		c.storeWeather(key, data)
+		return data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data.FetchDuration = time.Since(start)
+	return data, nil
+}
+
+// getJSON performs a GET request against url and decodes the JSON response
+// body into v, retrying according to the client's retry policy.
+func (c *Client) getJSON(ctx context.Context, url string, v interface{}) error {
+	_, err := c.fetchJSON(ctx, url, v)
+	return err
+}
+
+// doJSON performs a single GET request against url with the given extra
+// header (which may be nil) and decodes the JSON response body into v.
+// Unsuccessful responses are reported as *APIError.
+// The request waits for the client's rate limiter, if any.
+func (c *Client) doJSON(ctx context.Context, url string, header http.Header, v interface{}) error {
+	if err := c.limiter.wait(ctx); err != nil {
+		return err
+	}
+
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return fmt.Errorf("create request: %w", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("http request: %w", err)
+		return fmt.Errorf("http request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
-	}
-
-	var apiResp struct {
-		Current struct {
-			Temperature2M       float64 `json:"temperature_2m"`
-			ApparentTemperature float64 `json:"apparent_temperature"`
-			RelativeHumidity2M  float64 `json:"relative_humidity_2m"`
-			Precipitation       float64 `json:"precipitation"`
-			WeatherCode         int     `json:"weather_code"`
-			WindSpeed10M        float64 `json:"wind_speed_10m"`
-			WindDirection10M    float64 `json:"wind_direction_10m"`
-			WindGusts10M        float64 `json:"wind_gusts_10m"`
-			CloudCover          float64 `json:"cloud_cover"`
-			Visibility          float64 `json:"visibility"`
-			PressureMsl         float64 `json:"pressure_msl"`
-		} `json:"current"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
-	}
-
-	return &WeatherData{
-		Location:            location,
-		Temperature:         apiResp.Current.Temperature2M,
-		ApparentTemperature: apiResp.Current.ApparentTemperature,
-		Humidity:            apiResp.Current.RelativeHumidity2M,
-		Precipitation:       apiResp.Current.Precipitation,
-		WeatherCode:         apiResp.Current.WeatherCode,
-		WindSpeed:           apiResp.Current.WindSpeed10M,
-		WindDirection:       apiResp.Current.WindDirection10M,
-		WindGusts:           apiResp.Current.WindGusts10M,
-		CloudCover:          apiResp.Current.CloudCover,
-		Visibility:          apiResp.Current.Visibility,
-		Pressure:            apiResp.Current.PressureMsl,
-		FetchDuration:       time.Since(start),
-		Timestamp:           time.Now(),
-	}, nil
+	// 203 is used by MET Norway for deprecated but still valid responses
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNonAuthoritativeInfo {
+		return newAPIError(resp, url)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return &decodeError{err}
+	}
+
+	return nil
 }
 
 // FetchMultiple retrieves weather data for multiple locations concurrently.
-// All requests are performed in parallel using goroutines.
+// All requests are performed in parallel using goroutines, bounded by
+// WithMaxConcurrency if set. If WithBatchSize is set and the Open-Meteo
+// provider is in use, locations are grouped into multi-coordinate requests.
+// Results are in the same order as locations.
 //
 // Parameters:
 //   - ctx: context for cancellation and timeout control
 //   - locations: slice of locations to fetch weather for
+//   - opts: optional per-call settings applied to every location
 //
 // Returns:
 //   - []WeatherData slice containing results for all locations
 //   - Any errors are embedded in the individual WeatherData.Error field
-func (c *Client) FetchMultiple(ctx context.Context, locations []Location) []WeatherData {
+func (c *Client) FetchMultiple(ctx context.Context, locations []Location, opts ...FetchOption) []WeatherData {
+	// Name coordinate-only locations up front so failed results are labelled too
+	named := make([]Location, len(locations))
+	for i, location := range locations {
+		named[i] = c.autoName(ctx, location)
+	}
+	locations = named
+
+	if om, ok := c.provider.(*OpenMeteo); ok && c.batchSize > 1 {
+		return c.fetchBatched(ctx, om, locations, c.newQuery(opts))
+	}
+
 	results := make([]WeatherData, len(locations))
-	var wg sync.WaitGroup
-
-	for i, loc := range locations {
-		wg.Add(1)
-		go func(index int, location Location) {
-			defer wg.Done()
-
-			data, err := c.FetchWeather(ctx, location)
-			if err != nil {
-				results[index] = WeatherData{
-					Location: location,
-					Error:    err,
-				}
-				return
+
+	c.forEach(ctx, len(locations), func(i int) {
+		data, err := c.FetchWeather(ctx, locations[i], opts...)
+		if err != nil {
+			results[i] = WeatherData{
+				Location: locations[i],
+				Error:    err,
 			}
-			results[index] = *data
-		}(i, loc)
-	}
+			return
+		}
+		results[i] = *data
+	}, func(i int) {
+		results[i] = WeatherData{
+			Location: locations[i],
+			Error:    ctx.Err(),
+		}
+	})
 
-	wg.Wait()
 	return results
 }
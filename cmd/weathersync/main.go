@@ -4,9 +4,12 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
+	"math"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/krupki/weathersync"
@@ -21,6 +24,18 @@ type config struct {
 }
 
 func main() {
+	unitSystem := flag.String("units", "metric", "unit system: metric or imperial")
+	flag.Parse()
+
+	units := weathersync.Metric
+	switch *unitSystem {
+	case "metric":
+	case "imperial":
+		units = weathersync.Imperial
+	default:
+		log.Fatalf("Unknown unit system %q (want metric or imperial)", *unitSystem)
+	}
+
 	// Load configuration
 	cfg, err := loadConfig("config/cities.yaml")
 	if err != nil {
@@ -29,7 +44,8 @@ func main() {
 
 	// Create weathersync client
 	client := weathersync.New(
-		weathersync.WithTimeout(5 * time.Second),
+		weathersync.WithTimeout(5*time.Second),
+		weathersync.WithUnits(units),
 	)
 
 	// Prepare all locations
@@ -50,6 +66,35 @@ func main() {
 
 	// Display results grouped by continent
 	displayResults(results, continentMap)
+
+	// Fetch and display the 7-day outlook per continent
+	fmt.Println("\nFetching 7-day outlook...")
+	forecasts := fetchDailyForecasts(ctx, client, allLocations)
+	displayOutlook(forecasts, continentMap)
+}
+
+// fetchDailyForecasts retrieves a 7-day forecast for every location concurrently.
+// Locations whose forecast could not be fetched are reported and skipped.
+func fetchDailyForecasts(ctx context.Context, client *weathersync.Client, locations []weathersync.Location) []*weathersync.DailyForecast {
+	forecasts := make([]*weathersync.DailyForecast, len(locations))
+	var wg sync.WaitGroup
+
+	for i, loc := range locations {
+		wg.Add(1)
+		go func(index int, location weathersync.Location) {
+			defer wg.Done()
+
+			forecast, err := client.FetchDailyForecast(ctx, location, weathersync.ForecastOptions{Days: 7})
+			if err != nil {
+				log.Printf("%s: outlook unavailable: %v", location.Name, err)
+				return
+			}
+			forecasts[index] = forecast
+		}(i, loc)
+	}
+
+	wg.Wait()
+	return forecasts
 }
 
 func loadConfig(path string) (*config, error) {
@@ -84,24 +129,87 @@ func displayResults(results []weathersync.WeatherData, continentMap map[string]s
 
 		var tempSum float64
 		var validCount int
+		var units weathersync.Units
 
 		for _, d := range data {
 			if d.Error != nil {
 				fmt.Printf("   %s: ERROR - %v (%.3fs)\n",
 					d.Location.Name, d.Error, d.FetchDuration.Seconds())
 			} else {
-				fmt.Printf("   %s: %.1f°C (%.3fs)\n",
-					d.Location.Name, d.Temperature, d.FetchDuration.Seconds())
+				fmt.Printf("   %s: %.1f%s, wind %.1f %s (%.3fs)\n",
+					d.Location.Name, d.Temperature, d.Units.Temperature.Symbol(),
+					d.WindSpeed, d.Units.WindSpeed.Symbol(), d.FetchDuration.Seconds())
 				tempSum += d.Temperature
 				validCount++
+				units = d.Units
 			}
 		}
 
 		if validCount > 0 {
-			fmt.Printf("\n   Average: %.1f°C (%d cities)\n",
-				tempSum/float64(validCount), validCount)
+			fmt.Printf("\n   Average: %.1f%s (%d cities)\n",
+				tempSum/float64(validCount), units.Temperature.Symbol(), validCount)
 		}
 	}
 
 	fmt.Println("\n========================================")
 }
+
+func displayOutlook(forecasts []*weathersync.DailyForecast, continentMap map[string]string) {
+	// Group by continent
+	groups := make(map[string][]*weathersync.DailyForecast)
+	for _, f := range forecasts {
+		if f == nil {
+			continue
+		}
+		continent := continentMap[f.Location.Name]
+		groups[continent] = append(groups[continent], f)
+	}
+
+	fmt.Println("\n========================================")
+	fmt.Println("7-Day Outlook (continent averages)")
+	fmt.Println("========================================")
+
+	for continent, data := range groups {
+		fmt.Printf("\nContinent: %s\n", continent)
+		fmt.Println("----------------------------------------")
+
+		for day := range data[0].Days {
+			var maxTemps, minTemps, precips []float64
+
+			for _, f := range data {
+				if day >= len(f.Days) {
+					continue
+				}
+				maxTemps = append(maxTemps, f.Days[day].TemperatureMax)
+				minTemps = append(minTemps, f.Days[day].TemperatureMin)
+				precips = append(precips, f.Days[day].PrecipitationSum)
+			}
+
+			units := data[0].Units
+			fmt.Printf("   %s: %5.1f%s / %5.1f%s  %5.1f %s\n",
+				data[0].Days[day].Date.Format("Mon Jan 02"),
+				average(maxTemps), units.Temperature.Symbol(),
+				average(minTemps), units.Temperature.Symbol(),
+				average(precips), units.Precipitation.Symbol())
+		}
+	}
+
+	fmt.Println("\n========================================")
+}
+
+// average returns the mean of the values that are not NaN (missing), or NaN
+// if there are none.
+func average(values []float64) float64 {
+	var sum float64
+	var count int
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			sum += v
+			count++
+		}
+	}
+	if count == 0 {
+		return math.NaN()
+	}
+	return sum / float64(count)
+}
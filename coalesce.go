@@ -0,0 +1,117 @@
+package weathersync
+
+import (
+	"context"
+	"sync"
+)
+
+// flightGroup de-duplicates concurrent identical requests: callers asking for
+// the same key while a request is in flight wait for and share its result.
+// The zero value is ready to use.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// flightCall is a request in flight shared by one or more waiters.
+type flightCall struct {
+	done    chan struct{}
+	data    *WeatherData
+	err     error
+	expired bool // the call's own deadline passed before it finished
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// do calls fn once for all concurrent callers with the same key and returns
+// a copy of its result, maps included, to each of them.
+//
+// fn runs with its own context carrying the deadline of the caller that
+// started it, independent of that caller's cancellation: a caller whose ctx
+// is done stops waiting and returns ctx.Err(), while the request continues
+// for the remaining waiters. It is cancelled only once every waiter has given
+// up. Waiters with a later deadline than the one that expired start a new
+// request instead of failing.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) (*WeatherData, error)) (*WeatherData, error) {
+	for {
+		call := g.join(ctx, key, fn)
+
+		select {
+		case <-call.done:
+			if call.expired && ctx.Err() == nil {
+				continue
+			}
+			if call.err != nil {
+				return nil, call.err
+			}
+			data := call.data.clone()
+			return &data, nil
+
+		case <-ctx.Done():
+			g.leave(key, call)
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// join registers the caller as a waiter on the call in flight for key,
+// starting a new call if there is none.
+func (g *flightGroup) join(ctx context.Context, key string, fn func(ctx context.Context) (*WeatherData, error)) *flightCall {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+
+	call, ok := g.calls[key]
+	if !ok {
+		var callCtx context.Context
+		var cancel context.CancelFunc
+		if deadline, ok := ctx.Deadline(); ok {
+			callCtx, cancel = context.WithDeadline(context.Background(), deadline)
+		} else {
+			callCtx, cancel = context.WithCancel(context.Background())
+		}
+		call = &flightCall{
+			done:   make(chan struct{}),
+			cancel: cancel,
+		}
+		g.calls[key] = call
+
+		go func() {
+			call.data, call.err = fn(callCtx)
+			call.expired = callCtx.Err() == context.DeadlineExceeded
+			g.forget(key, call)
+			cancel()
+			close(call.done)
+		}()
+	}
+
+	call.waiters++
+	return call
+}
+
+// leave unregisters a waiter that gave up, cancelling the call if it was the
+// last one. Later callers then start afresh.
+func (g *flightGroup) leave(key string, call *flightCall) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	call.waiters--
+	if call.waiters == 0 {
+		if g.calls[key] == call {
+			delete(g.calls, key)
+		}
+		call.cancel()
+	}
+}
+
+// forget removes call from the group if it is still registered under key.
+func (g *flightGroup) forget(key string, call *flightCall) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+}
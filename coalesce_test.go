@@ -0,0 +1,114 @@
+package weathersync
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestFetchWeatherCoalesced tests that concurrent identical requests share
+// one API call and each receive the result
+func TestFetchWeatherCoalesced(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"current": {"temperature_2m": 15.3, "uv_index": 4.5}}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL), WithVariables(Temperature, UVIndex))
+	location := Location{Name: "Berlin", Latitude: 52.52, Longitude: 13.41}
+
+	var wg sync.WaitGroup
+	results := make([]*WeatherData, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			data, err := client.FetchWeather(context.Background(), location)
+			if err != nil {
+				t.Errorf("Request %d failed: %v", index, err)
+				return
+			}
+			results[index] = data
+		}(i)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Expected 1 API call, got %d", got)
+	}
+
+	for i, data := range results {
+		if data == nil || data.Temperature != 15.3 {
+			t.Errorf("Result %d: unexpected data %+v", i, data)
+		}
+	}
+
+	// Each caller gets its own copy
+	if results[0] == results[1] {
+		t.Error("Expected callers to receive distinct WeatherData values")
+	}
+
+	results[0].Extra["uv_index"] = 0
+	if results[1].Extra["uv_index"] != 4.5 {
+		t.Errorf("Expected callers to receive distinct Extra maps, got uv_index %v", results[1].Extra["uv_index"])
+	}
+}
+
+// TestFetchWeatherCoalescedCancel tests that one caller cancelling does not
+// abort the shared request for the others
+func TestFetchWeatherCoalescedCancel(t *testing.T) {
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"current": {"temperature_2m": 15.3}}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL))
+	location := Location{Name: "Berlin", Latitude: 52.52, Longitude: 13.41}
+
+	// The first caller starts the request, then gives up
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := client.FetchWeather(ctx, location)
+		firstErr <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	secondDone := make(chan *WeatherData, 1)
+	go func() {
+		data, err := client.FetchWeather(context.Background(), location)
+		if err != nil {
+			t.Errorf("Second caller failed: %v", err)
+		}
+		secondDone <- data
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled for first caller, got %v", err)
+	}
+
+	close(release)
+	if data := <-secondDone; data == nil || data.Temperature != 15.3 {
+		t.Errorf("Unexpected data for second caller: %+v", data)
+	}
+}
@@ -0,0 +1,74 @@
+package weathersync
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// maxElevationBatch is the maximum number of coordinates the Open-Meteo
+// elevation API accepts per request.
+const maxElevationBatch = 100
+
+// Elevation looks up the terrain elevation of locations with the Open-Meteo
+// elevation API (90 m digital elevation model) and returns copies of them
+// with Elevation set, in the same order. Up to 100 locations are looked up
+// per request.
+func (c *Client) Elevation(ctx context.Context, locations []Location) ([]Location, error) {
+	results := make([]Location, len(locations))
+	copy(results, locations)
+
+	for offset := 0; offset < len(results); offset += maxElevationBatch {
+		end := offset + maxElevationBatch
+		if end > len(results) {
+			end = len(results)
+		}
+		batch := results[offset:end]
+
+		lats := make([]string, len(batch))
+		lons := make([]string, len(batch))
+		for i, location := range batch {
+			lats[i] = fmt.Sprintf("%f", location.Latitude)
+			lons[i] = fmt.Sprintf("%f", location.Longitude)
+		}
+
+		url := fmt.Sprintf("%s/v1/elevation?latitude=%s&longitude=%s",
+			c.apiURL, strings.Join(lats, ","), strings.Join(lons, ","))
+
+		var apiResp struct {
+			Elevation []float64 `json:"elevation"`
+		}
+		if err := c.getJSON(ctx, url, &apiResp); err != nil {
+			return nil, err
+		}
+		if len(apiResp.Elevation) != len(batch) {
+			return nil, &decodeError{fmt.Errorf("elevation response has %d results, want %d", len(apiResp.Elevation), len(batch))}
+		}
+
+		for i, elevation := range apiResp.Elevation {
+			batch[i].Elevation = elevation
+		}
+	}
+
+	return results, nil
+}
+
+// elevationParam returns the query string fragment passing the elevation of
+// locations to the forecast API, or "" if any of them is unknown; the API
+// then downscales to its own 90 m elevation model. Unknown elevations cannot
+// be sent as "nan" within a batch, since that turns off downscaling and
+// yields grid-cell averages, so fetchBatched keeps them in separate batches.
+func elevationParam(locations ...Location) string {
+	values := make([]string, len(locations))
+	for i, location := range locations {
+		if location.Elevation == 0 {
+			return ""
+		}
+		values[i] = fmt.Sprintf("%g", location.Elevation)
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+	return "&elevation=" + strings.Join(values, ",")
+}
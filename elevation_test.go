@@ -0,0 +1,125 @@
+package weathersync
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// TestElevation tests batched elevation lookups
+func TestElevation(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		if r.URL.Path != "/v1/elevation" {
+			t.Errorf("Expected path /v1/elevation, got %s", r.URL.Path)
+		}
+
+		// Answer each latitude with itself as elevation
+		lats := strings.Split(r.URL.Query().Get("latitude"), ",")
+		if len(lats) > maxElevationBatch {
+			t.Errorf("Expected at most %d coordinates, got %d", maxElevationBatch, len(lats))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"elevation": [%s]}`, strings.Join(lats, ","))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL))
+
+	locations := make([]Location, 150)
+	for i := range locations {
+		locations[i] = Location{Name: fmt.Sprintf("Site %d", i), Latitude: float64(i)}
+	}
+
+	results, err := client.Elevation(context.Background(), locations)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("Expected 2 requests, got %d", got)
+	}
+
+	for i, result := range results {
+		if result.Elevation != float64(i) || result.Name != locations[i].Name {
+			t.Fatalf("Result %d: unexpected location %+v", i, result)
+		}
+	}
+
+	if locations[149].Elevation != 0 {
+		t.Error("Expected input locations to be left unchanged")
+	}
+}
+
+// TestFetchWeatherElevation tests that known elevations are passed to the
+// forecast API and that batches do not mix known and unknown elevations
+func TestFetchWeatherElevation(t *testing.T) {
+	var mu sync.Mutex
+	elevations := make(map[string]string) // latitude parameter -> elevation parameter
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		mu.Lock()
+		elevations[query.Get("latitude")] = query.Get("elevation")
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		lats := strings.Split(query.Get("latitude"), ",")
+		if len(lats) == 1 {
+			w.Write([]byte(`{"current": {"temperature_2m": -3.5}}`))
+			return
+		}
+		parts := make([]string, len(lats))
+		for i := range lats {
+			parts[i] = `{"current": {"temperature_2m": 1.0}}`
+		}
+		w.Write([]byte("[" + strings.Join(parts, ",") + "]"))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL))
+
+	if _, err := client.FetchWeather(context.Background(), Location{Name: "Zugspitze", Latitude: 47.42, Longitude: 10.98, Elevation: 2962}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := elevations["47.420000"]; got != "2962" {
+		t.Errorf("Expected elevation=2962, got %q", got)
+	}
+
+	if _, err := client.FetchWeather(context.Background(), Location{Name: "Munich", Latitude: 48.14, Longitude: 11.58}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := elevations["48.140000"]; got != "" {
+		t.Errorf("Expected no elevation parameter, got %q", got)
+	}
+
+	elevations = make(map[string]string)
+	batched := New(WithAPIURL(server.URL), WithBatchSize(10))
+	results := batched.FetchMultiple(context.Background(), []Location{
+		{Name: "Zugspitze", Latitude: 47.42, Longitude: 10.98, Elevation: 2962},
+		{Name: "Munich", Latitude: 48.14, Longitude: 11.58},
+		{Name: "Garmisch", Latitude: 47.49, Longitude: 11.1, Elevation: 708},
+		{Name: "Augsburg", Latitude: 48.37, Longitude: 10.9},
+	})
+
+	for i, result := range results {
+		if result.Error != nil {
+			t.Errorf("Result %d: unexpected error %v", i, result.Error)
+		}
+	}
+
+	want := map[string]string{
+		"47.420000,47.490000": "2962,708",
+		"48.140000,48.370000": "",
+	}
+	if fmt.Sprint(elevations) != fmt.Sprint(want) {
+		t.Errorf("Expected batch requests %v, got %v", want, elevations)
+	}
+}
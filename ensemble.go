@@ -0,0 +1,272 @@
+package weathersync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Ensemble is a Provider that queries several providers concurrently and
+// merges their answers. Numeric fields are combined with the median, or a
+// weighted mean if EnsembleWeights is set; WeatherData.Spread reports how
+// far the answers were apart per field. Providers that fail are left out of
+// the merge, and so are providers that did not report a field (NaN) from the
+// merge of that field; the ensemble fails only if none of them answered.
+type Ensemble struct {
+	providers []Provider
+	weights   []float64
+	timeout   time.Duration
+}
+
+// EnsembleOption configures an Ensemble provider.
+type EnsembleOption func(*Ensemble)
+
+// EnsembleWeights switches from the median to a weighted mean, with one
+// weight per provider in the order they were passed to NewEnsemble.
+func EnsembleWeights(weights ...float64) EnsembleOption {
+	return func(e *Ensemble) {
+		e.weights = weights
+	}
+}
+
+// EnsembleTimeout limits the time each provider gets to answer.
+// Default is no limit beyond the caller's context.
+func EnsembleTimeout(d time.Duration) EnsembleOption {
+	return func(e *Ensemble) {
+		e.timeout = d
+	}
+}
+
+// NewEnsemble creates an Ensemble provider merging the given providers.
+func NewEnsemble(providers []Provider, opts ...EnsembleOption) *Ensemble {
+	e := &Ensemble{providers: providers}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// Name implements Provider.
+func (e *Ensemble) Name() string {
+	names := make([]string, len(e.providers))
+	for i, p := range e.providers {
+		names[i] = p.Name()
+	}
+	return "ensemble(" + strings.Join(names, ",") + ")"
+}
+
+func (e *Ensemble) bind(c *Client) {
+	for _, p := range e.providers {
+		if b, ok := p.(binder); ok {
+			b.bind(c)
+		}
+	}
+}
+
+// ensembleFields lists the numeric WeatherData fields merged by Ensemble.
+// WeatherCode and WindDirection need special treatment and are handled separately.
+var ensembleFields = []struct {
+	name  string
+	field func(*WeatherData) *float64
+}{
+	{"Temperature", func(d *WeatherData) *float64 { return &d.Temperature }},
+	{"ApparentTemperature", func(d *WeatherData) *float64 { return &d.ApparentTemperature }},
+	{"Humidity", func(d *WeatherData) *float64 { return &d.Humidity }},
+	{"Precipitation", func(d *WeatherData) *float64 { return &d.Precipitation }},
+	{"WindSpeed", func(d *WeatherData) *float64 { return &d.WindSpeed }},
+	{"WindGusts", func(d *WeatherData) *float64 { return &d.WindGusts }},
+	{"CloudCover", func(d *WeatherData) *float64 { return &d.CloudCover }},
+	{"Visibility", func(d *WeatherData) *float64 { return &d.Visibility }},
+	{"Pressure", func(d *WeatherData) *float64 { return &d.Pressure }},
+}
+
+// Current implements Provider. All providers are queried in parallel.
+func (e *Ensemble) Current(ctx context.Context, location Location) (*WeatherData, error) {
+	if len(e.providers) == 0 {
+		return nil, errors.New("ensemble: no providers configured")
+	}
+	if e.weights != nil && len(e.weights) != len(e.providers) {
+		return nil, fmt.Errorf("ensemble: %d weights for %d providers", len(e.weights), len(e.providers))
+	}
+
+	start := time.Now()
+
+	answers := make([]*WeatherData, len(e.providers))
+	errs := make([]error, len(e.providers))
+	var wg sync.WaitGroup
+
+	for i, p := range e.providers {
+		wg.Add(1)
+		go func(index int, provider Provider) {
+			defer wg.Done()
+
+			pctx := ctx
+			if e.timeout > 0 {
+				var cancel context.CancelFunc
+				pctx, cancel = context.WithTimeout(ctx, e.timeout)
+				defer cancel()
+			}
+
+			answers[index], errs[index] = provider.Current(pctx, location)
+			if errs[index] == nil && answers[index] == nil {
+				errs[index] = errors.New("no data")
+			}
+		}(i, p)
+	}
+
+	wg.Wait()
+
+	var members []*WeatherData
+	var weights []float64
+	var sources, failures []string
+	for i, data := range answers {
+		if errs[i] != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", e.providers[i].Name(), errs[i]))
+			continue
+		}
+		// Merge in common units; the Client converts the result
+		data.convertUnits(Metric)
+		members = append(members, data)
+		sources = append(sources, e.providers[i].Name())
+		if e.weights != nil {
+			weights = append(weights, e.weights[i])
+		} else {
+			weights = append(weights, 1)
+		}
+	}
+
+	if len(members) == 0 {
+		return nil, fmt.Errorf("ensemble: all providers failed (%s)", strings.Join(failures, "; "))
+	}
+
+	merged := &WeatherData{
+		Location:      location,
+		Units:         Metric,
+		Spread:        make(map[string]float64, len(ensembleFields)+1),
+		Source:        "ensemble(" + strings.Join(sources, ",") + ")",
+		FetchDuration: time.Since(start),
+		Timestamp:     time.Now(),
+	}
+
+	values := make([]float64, len(members))
+	for _, f := range ensembleFields {
+		for i, m := range members {
+			values[i] = *f.field(m)
+		}
+		v, w := reported(values, weights)
+		switch {
+		case len(v) == 0:
+			*f.field(merged) = math.NaN()
+			continue
+		case e.weights != nil:
+			*f.field(merged) = weightedMean(v, w)
+		default:
+			*f.field(merged) = median(v)
+		}
+		merged.Spread[f.name] = spread(v)
+	}
+
+	directions := make([]float64, len(members))
+	codes := make([]int, len(members))
+	for i, m := range members {
+		directions[i] = m.WindDirection
+		codes[i] = m.WeatherCode
+		merged.Attempts += m.Attempts
+	}
+	merged.WindDirection = math.NaN()
+	if d, w := reported(directions, weights); len(d) > 0 {
+		merged.WindDirection, merged.Spread["WindDirection"] = circularMean(d, w)
+	}
+	merged.WeatherCode = modeCode(codes)
+
+	return merged, nil
+}
+
+// reported returns the values that are not NaN, along with their weights.
+func reported(values, weights []float64) ([]float64, []float64) {
+	var v, w []float64
+	for i, x := range values {
+		if !math.IsNaN(x) {
+			v = append(v, x)
+			w = append(w, weights[i])
+		}
+	}
+	return v, w
+}
+
+// median returns the median of values, which must not be empty.
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// weightedMean returns the mean of values weighted by weights.
+func weightedMean(values, weights []float64) float64 {
+	var sum, total float64
+	for i, v := range values {
+		sum += v * weights[i]
+		total += weights[i]
+	}
+	if total == 0 {
+		return math.NaN()
+	}
+	return sum / total
+}
+
+// spread returns the difference between the largest and smallest value.
+func spread(values []float64) float64 {
+	lo, hi := values[0], values[0]
+	for _, v := range values[1:] {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	return hi - lo
+}
+
+// circularMean returns the weighted mean of angles in degrees (0-360) and
+// the largest deviation of any angle from it, so that e.g. 350° and 10°
+// average to 0° rather than 180°.
+func circularMean(degrees, weights []float64) (float64, float64) {
+	var x, y float64
+	for i, d := range degrees {
+		rad := d * math.Pi / 180
+		x += math.Cos(rad) * weights[i]
+		y += math.Sin(rad) * weights[i]
+	}
+
+	mean := math.Mod(math.Atan2(y, x)*180/math.Pi+360, 360)
+
+	var maxDev float64
+	for _, d := range degrees {
+		dev := math.Abs(math.Mod(d-mean+540, 360) - 180)
+		maxDev = math.Max(maxDev, dev)
+	}
+	return mean, maxDev
+}
+
+// modeCode returns the most common weather code, preferring the higher
+// (more severe) code on ties.
+func modeCode(codes []int) int {
+	counts := make(map[int]int, len(codes))
+	best, bestCount := 0, 0
+	for _, c := range codes {
+		counts[c]++
+		if counts[c] > bestCount || (counts[c] == bestCount && c > best) {
+			best, bestCount = c, counts[c]
+		}
+	}
+	return best
+}
@@ -0,0 +1,195 @@
+package weathersync
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+)
+
+// dirProvider is a stub returning a fixed temperature, wind direction and weather code
+type dirProvider struct {
+	name string
+	temp float64
+	dir  float64
+	code int
+}
+
+func (p *dirProvider) Name() string {
+	return p.name
+}
+
+func (p *dirProvider) Current(ctx context.Context, location Location) (*WeatherData, error) {
+	return &WeatherData{Location: location, Temperature: p.temp, WindDirection: p.dir, WeatherCode: p.code}, nil
+}
+
+// TestEnsembleMedian tests merging by median, spread and tolerance of failures
+func TestEnsembleMedian(t *testing.T) {
+	ensemble := NewEnsemble([]Provider{
+		&dirProvider{name: "a", temp: 10, dir: 350, code: 3},
+		&dirProvider{name: "b", temp: 14, dir: 10, code: 61},
+		&dirProvider{name: "c", temp: 11, dir: 0, code: 3},
+		&stubProvider{name: "down", err: errors.New("outage")},
+	})
+
+	client := New(WithProvider(ensemble))
+
+	data, err := client.FetchWeather(context.Background(), Location{Name: "Berlin"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if data.Temperature != 11 {
+		t.Errorf("Expected median temperature 11, got %f", data.Temperature)
+	}
+
+	if data.Spread["Temperature"] != 4 {
+		t.Errorf("Expected temperature spread 4, got %f", data.Spread["Temperature"])
+	}
+
+	if d := math.Mod(data.WindDirection+180, 360) - 180; math.Abs(d) > 1e-9 {
+		t.Errorf("Expected wind direction 0, got %f", data.WindDirection)
+	}
+
+	if math.Abs(data.Spread["WindDirection"]-10) > 1e-9 {
+		t.Errorf("Expected wind direction spread 10, got %f", data.Spread["WindDirection"])
+	}
+
+	if data.WeatherCode != 3 {
+		t.Errorf("Expected weather code 3, got %d", data.WeatherCode)
+	}
+
+	if data.Source != "ensemble(a,b,c)" {
+		t.Errorf("Unexpected source %q", data.Source)
+	}
+}
+
+// dataProvider is a stub returning a copy of fixed data
+type dataProvider struct {
+	name string
+	data WeatherData
+}
+
+func (p *dataProvider) Name() string {
+	return p.name
+}
+
+func (p *dataProvider) Current(ctx context.Context, location Location) (*WeatherData, error) {
+	data := p.data
+	data.Location = location
+	return &data, nil
+}
+
+// TestEnsemblePartial tests that fields a provider did not report are left
+// out of the merge instead of counting as zero
+func TestEnsemblePartial(t *testing.T) {
+	nan := math.NaN()
+	full := WeatherData{
+		Temperature: 10, ApparentTemperature: 8, Humidity: 60, Precipitation: 0.2,
+		WindSpeed: 12, WindDirection: 90, WindGusts: 30, CloudCover: 75,
+		Visibility: 10000, Pressure: 1012,
+	}
+	// Like MET Norway: no apparent temperature, gusts or visibility
+	partial := WeatherData{
+		Temperature: 12, ApparentTemperature: nan, Humidity: 70, Precipitation: 0.4,
+		WindSpeed: 14, WindDirection: nan, WindGusts: nan, CloudCover: 65,
+		Visibility: nan, Pressure: nan,
+	}
+	// Reports nothing but the temperature
+	sparse := WeatherData{
+		Temperature: 11, ApparentTemperature: nan, Humidity: nan, Precipitation: nan,
+		WindSpeed: nan, WindDirection: nan, WindGusts: nan, CloudCover: nan,
+		Visibility: nan, Pressure: nan,
+	}
+
+	for _, opts := range [][]EnsembleOption{nil, {EnsembleWeights(1, 1, 1)}} {
+		ensemble := NewEnsemble([]Provider{
+			&dataProvider{name: "full", data: full},
+			&dataProvider{name: "partial", data: partial},
+			&dataProvider{name: "sparse", data: sparse},
+		}, opts...)
+
+		client := New(WithProvider(ensemble))
+
+		data, err := client.FetchWeather(context.Background(), Location{Name: "Oslo"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if data.Temperature != 11 || data.Spread["Temperature"] != 2 {
+			t.Errorf("Expected temperature 11 (spread 2), got %f (spread %f)", data.Temperature, data.Spread["Temperature"])
+		}
+
+		if data.Visibility != 10000 || data.Spread["Visibility"] != 0 {
+			t.Errorf("Expected visibility 10000 (spread 0), got %f (spread %f)", data.Visibility, data.Spread["Visibility"])
+		}
+
+		if data.WindGusts != 30 || data.ApparentTemperature != 8 || data.Pressure != 1012 {
+			t.Errorf("Expected values of the only reporting provider, got %+v", data)
+		}
+
+		if data.Humidity != 65 || data.Spread["Humidity"] != 10 {
+			t.Errorf("Expected humidity 65 (spread 10), got %f (spread %f)", data.Humidity, data.Spread["Humidity"])
+		}
+
+		if math.Abs(data.WindDirection-90) > 1e-9 || data.Spread["WindDirection"] != 0 {
+			t.Errorf("Expected wind direction 90 (spread 0), got %f (spread %f)", data.WindDirection, data.Spread["WindDirection"])
+		}
+	}
+}
+
+// TestEnsembleUnreported tests that a field no provider reported stays NaN
+func TestEnsembleUnreported(t *testing.T) {
+	nan := math.NaN()
+	ensemble := NewEnsemble([]Provider{
+		&dataProvider{name: "a", data: WeatherData{Temperature: 10, Visibility: nan, WindDirection: nan}},
+		&dataProvider{name: "b", data: WeatherData{Temperature: 12, Visibility: nan, WindDirection: nan}},
+	})
+
+	client := New(WithProvider(ensemble))
+
+	data, err := client.FetchWeather(context.Background(), Location{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !math.IsNaN(data.Visibility) || !math.IsNaN(data.WindDirection) {
+		t.Errorf("Expected NaN visibility and wind direction, got %f/%f", data.Visibility, data.WindDirection)
+	}
+
+	if _, ok := data.Spread["Visibility"]; ok {
+		t.Error("Expected no spread for an unreported field")
+	}
+	if _, ok := data.Spread["WindDirection"]; ok {
+		t.Error("Expected no spread for an unreported wind direction")
+	}
+}
+
+// TestEnsembleWeightedMean tests merging by weighted mean
+func TestEnsembleWeightedMean(t *testing.T) {
+	ensemble := NewEnsemble([]Provider{
+		&dirProvider{name: "a", temp: 10},
+		&dirProvider{name: "b", temp: 20},
+	}, EnsembleWeights(3, 1))
+
+	client := New(WithProvider(ensemble))
+
+	data, err := client.FetchWeather(context.Background(), Location{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if data.Temperature != 12.5 {
+		t.Errorf("Expected weighted temperature 12.5, got %f", data.Temperature)
+	}
+}
+
+// TestEnsembleAllFail tests that an ensemble without answers fails
+func TestEnsembleAllFail(t *testing.T) {
+	ensemble := NewEnsemble([]Provider{&stubProvider{name: "down", err: errors.New("outage")}})
+	client := New(WithProvider(ensemble))
+
+	if _, err := client.FetchWeather(context.Background(), Location{}); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
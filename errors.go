@@ -0,0 +1,131 @@
+package weathersync
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Sentinel errors for use with errors.Is.
+var (
+	// ErrRateLimited is matched by API errors with status 429 Too Many Requests.
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrInvalidLocation is matched by API errors rejecting the coordinates
+	// of the requested location.
+	ErrInvalidLocation = errors.New("invalid location")
+
+	// ErrDecode is matched by errors caused by a malformed or unexpected
+	// response body.
+	ErrDecode = errors.New("decode response")
+
+	// ErrPlaceNotFound is returned by FetchWeatherByName when no place
+	// matches the given name.
+	ErrPlaceNotFound = errors.New("place not found")
+
+	// ErrNoMarineData is matched by NoMarineDataError, returned by
+	// FetchMarine for locations on land or outside the marine model.
+	ErrNoMarineData = errors.New("no marine data")
+)
+
+// APIError is returned when the weather API responds with an error status.
+// Use errors.As to inspect it:
+//
+//	var apiErr *weathersync.APIError
+//	if errors.As(err, &apiErr) {
+//		log.Printf("status %d: %s", apiErr.StatusCode, apiErr.Reason)
+//	}
+type APIError struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+
+	// Reason is the explanation sent by the API, if any
+	Reason string
+
+	// URL is the request URL that failed
+	URL string
+
+	// Retryable reports whether the request may succeed if repeated
+	// (status 429, 502, 503 or 504)
+	Retryable bool
+
+	// RetryAfter is the delay requested by the Retry-After header of a
+	// 429 or 503 response, if any
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("API returned status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("API returned status %d: %s", e.StatusCode, e.Reason)
+}
+
+// Is makes APIError match ErrRateLimited and ErrInvalidLocation.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrInvalidLocation:
+		if e.StatusCode != http.StatusBadRequest {
+			return false
+		}
+		reason := strings.ToLower(e.Reason)
+		return strings.Contains(reason, "latitude") || strings.Contains(reason, "longitude")
+	}
+	return false
+}
+
+// newAPIError builds an APIError from a non-200 response, reading the
+// Open-Meteo {"error": true, "reason": "..."} body if present.
+func newAPIError(resp *http.Response, url string) *APIError {
+	var body struct {
+		Reason string `json:"reason"`
+	}
+	// A missing or non-JSON body simply leaves Reason empty
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&body)
+
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Reason:     body.Reason,
+		URL:        url,
+		Retryable:  retryableStatus(resp.StatusCode),
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		apiErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+	}
+
+	return apiErr
+}
+
+// retryableStatus reports whether a response status indicates a transient failure.
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// decodeError reports a response body that could not be interpreted.
+// It matches ErrDecode and unwraps to the underlying error.
+type decodeError struct {
+	err error
+}
+
+func (e *decodeError) Error() string {
+	return "decode response: " + e.err.Error()
+}
+
+func (e *decodeError) Unwrap() error {
+	return e.err
+}
+
+func (e *decodeError) Is(target error) bool {
+	return target == ErrDecode
+}
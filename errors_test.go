@@ -0,0 +1,88 @@
+package weathersync
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestFetchWeatherAPIErrorReason tests that the Open-Meteo reason text is
+// exposed through *APIError
+func TestFetchWeatherAPIErrorReason(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": true, "reason": "Latitude must be in range of -90 to 90°. Given: 100.0."}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL))
+
+	_, err := client.FetchWeather(context.Background(), Location{Latitude: 100})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got %T: %v", err, err)
+	}
+
+	if apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", apiErr.StatusCode)
+	}
+
+	if apiErr.Reason != "Latitude must be in range of -90 to 90°. Given: 100.0." {
+		t.Errorf("Unexpected reason %q", apiErr.Reason)
+	}
+
+	if apiErr.URL == "" || apiErr.Retryable {
+		t.Errorf("Expected URL set and not retryable, got %+v", apiErr)
+	}
+
+	if !errors.Is(err, ErrInvalidLocation) {
+		t.Error("Expected errors.Is(err, ErrInvalidLocation)")
+	}
+
+	if errors.Is(err, ErrRateLimited) {
+		t.Error("Did not expect errors.Is(err, ErrRateLimited)")
+	}
+}
+
+// TestAPIErrorSentinels tests matching of API errors against sentinel errors
+func TestAPIErrorSentinels(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    *APIError
+		target error
+		want   bool
+	}{
+		{name: "429 is rate limited", err: &APIError{StatusCode: 429}, target: ErrRateLimited, want: true},
+		{name: "503 is not rate limited", err: &APIError{StatusCode: 503}, target: ErrRateLimited, want: false},
+		{name: "400 longitude", err: &APIError{StatusCode: 400, Reason: "Longitude must be in range"}, target: ErrInvalidLocation, want: true},
+		{name: "400 other reason", err: &APIError{StatusCode: 400, Reason: "Cannot initialize WeatherVariable"}, target: ErrInvalidLocation, want: false},
+		{name: "not a decode error", err: &APIError{StatusCode: 500}, target: ErrDecode, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.err, tt.target); got != tt.want {
+				t.Errorf("errors.Is = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFetchWeatherDecodeError tests that malformed bodies match ErrDecode
+func TestFetchWeatherDecodeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("invalid json {"))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL))
+
+	_, err := client.FetchWeather(context.Background(), Location{})
+	if !errors.Is(err, ErrDecode) {
+		t.Fatalf("Expected ErrDecode, got %v", err)
+	}
+}
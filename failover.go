@@ -0,0 +1,140 @@
+package weathersync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Failover is a Provider that tries an ordered list of providers and
+// returns the first acceptable answer. A provider is skipped if it fails,
+// exceeds the per-provider timeout or returns data rejected by the
+// validator. WeatherData.Source records which provider served the data.
+type Failover struct {
+	providers []Provider
+	timeout   time.Duration
+	validate  func(*WeatherData) error
+}
+
+// FailoverOption configures a Failover provider.
+type FailoverOption func(*Failover)
+
+// FailoverTimeout limits the time each provider gets before the next one
+// is tried. Default is no limit beyond the caller's context.
+func FailoverTimeout(d time.Duration) FailoverOption {
+	return func(f *Failover) {
+		f.timeout = d
+	}
+}
+
+// FailoverValidate sets the check deciding whether a provider's answer is
+// usable; a non-nil error moves on to the next provider. The default
+// rejects data without a temperature (NaN), unless the variables selected
+// with WithVariables or Variables leave out Temperature.
+func FailoverValidate(validate func(*WeatherData) error) FailoverOption {
+	return func(f *Failover) {
+		f.validate = validate
+	}
+}
+
+// NewFailover creates a Failover provider trying providers in order.
+func NewFailover(providers []Provider, opts ...FailoverOption) *Failover {
+	f := &Failover{providers: providers}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
+}
+
+// requireTemperature is the default Failover validator. ctx carries the
+// settings of the request, if any.
+func requireTemperature(ctx context.Context, data *WeatherData) error {
+	if q, ok := queryFrom(ctx); ok && !q.requests(Temperature) {
+		return nil
+	}
+	if math.IsNaN(data.Temperature) {
+		return errors.New("missing temperature")
+	}
+	return nil
+}
+
+// Name implements Provider.
+func (f *Failover) Name() string {
+	names := make([]string, len(f.providers))
+	for i, p := range f.providers {
+		names[i] = p.Name()
+	}
+	return "failover(" + strings.Join(names, ",") + ")"
+}
+
+func (f *Failover) bind(c *Client) {
+	for _, p := range f.providers {
+		if b, ok := p.(binder); ok {
+			b.bind(c)
+		}
+	}
+}
+
+// Current implements Provider.
+func (f *Failover) Current(ctx context.Context, location Location) (*WeatherData, error) {
+	if len(f.providers) == 0 {
+		return nil, errors.New("failover: no providers configured")
+	}
+
+	var failures []string
+	var lastErr error
+
+	for _, p := range f.providers {
+		data, err := f.try(ctx, p, location)
+		if err == nil {
+			return data, nil
+		}
+
+		// The caller gave up; don't try the remaining providers
+		if ctx.Err() != nil {
+			return nil, err
+		}
+
+		failures = append(failures, fmt.Sprintf("%s: %v", p.Name(), err))
+		lastErr = err
+	}
+
+	return nil, fmt.Errorf("failover: all providers failed (%s): %w", strings.Join(failures, "; "), lastErr)
+}
+
+// try fetches from a single provider, applying the per-provider timeout
+// and the validator.
+func (f *Failover) try(ctx context.Context, p Provider, location Location) (*WeatherData, error) {
+	if f.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, f.timeout)
+		defer cancel()
+	}
+
+	data, err := p.Current(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, errors.New("no data")
+	}
+	if f.validate != nil {
+		err = f.validate(data)
+	} else {
+		err = requireTemperature(ctx, data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the innermost source when failovers are nested
+	if data.Source == "" {
+		data.Source = p.Name()
+	}
+	return data, nil
+}
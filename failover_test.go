@@ -0,0 +1,136 @@
+package weathersync
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// slowProvider blocks until its context is done
+type slowProvider struct{}
+
+func (slowProvider) Name() string {
+	return "slow"
+}
+
+func (slowProvider) Current(ctx context.Context, location Location) (*WeatherData, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// TestFailoverFallsBack tests that errors, timeouts and missing fields move
+// on to the next provider and that the serving provider is recorded
+func TestFailoverFallsBack(t *testing.T) {
+	failover := NewFailover([]Provider{
+		&stubProvider{name: "broken", err: errors.New("outage")},
+		slowProvider{},
+		&stubProvider{name: "empty", temp: math.NaN()},
+		&stubProvider{name: "backup", temp: 12.5},
+	}, FailoverTimeout(20*time.Millisecond))
+
+	client := New(WithProvider(failover))
+
+	data, err := client.FetchWeather(context.Background(), Location{Name: "Berlin"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if data.Source != "backup" {
+		t.Errorf("Expected source backup, got %q", data.Source)
+	}
+
+	if data.Temperature != 12.5 {
+		t.Errorf("Expected temperature 12.5, got %f", data.Temperature)
+	}
+}
+
+// TestFailoverMissingTemperature tests that an Open-Meteo response without
+// temperature_2m is rejected by the default validator
+func TestFailoverMissingTemperature(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"current": {"time": "2024-06-01T12:00", "wind_speed_10m": 12.0}}`))
+	}))
+	defer server.Close()
+
+	failover := NewFailover([]Provider{
+		NewOpenMeteo(server.URL),
+		&stubProvider{name: "backup", temp: 12.5},
+	})
+
+	client := New(WithProvider(failover))
+
+	data, err := client.FetchWeather(context.Background(), Location{Name: "Berlin"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if data.Source != "backup" || data.Temperature != 12.5 {
+		t.Errorf("Expected backup temperature 12.5, got %f from %q", data.Temperature, data.Source)
+	}
+}
+
+// TestFailoverVariablesWithoutTemperature tests that the default validator
+// accepts data without a temperature if none was requested
+func TestFailoverVariablesWithoutTemperature(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"current": {"time": "2024-06-01T12:00", "uv_index": 6.5}}`))
+	}))
+	defer server.Close()
+
+	client := New(WithVariables(UVIndex), WithProvider(NewFailover([]Provider{NewOpenMeteo(server.URL)})))
+
+	data, err := client.FetchWeather(context.Background(), Location{Name: "Berlin"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if data.Source != "open-meteo" || data.Extra["uv_index"] != 6.5 {
+		t.Errorf("Expected uv_index 6.5 from open-meteo, got %v from %q", data.Extra, data.Source)
+	}
+
+	// Requesting the temperature again makes it mandatory
+	if _, err := client.FetchWeather(context.Background(), Location{Name: "Berlin"}, Variables(Temperature, UVIndex)); err == nil {
+		t.Error("Expected error for missing requested temperature, got nil")
+	}
+}
+
+// TestFailoverAllFail tests that the error lists every provider and wraps the last error
+func TestFailoverAllFail(t *testing.T) {
+	lastErr := errors.New("second outage")
+	failover := NewFailover([]Provider{
+		&stubProvider{name: "first", err: errors.New("first outage")},
+		&stubProvider{name: "second", err: lastErr},
+	})
+
+	client := New(WithProvider(failover))
+
+	_, err := client.FetchWeather(context.Background(), Location{})
+	if !errors.Is(err, lastErr) {
+		t.Fatalf("Expected wrapped last error, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "first outage") {
+		t.Errorf("Expected error to mention every provider, got %v", err)
+	}
+}
+
+// TestFetchWeatherSource tests that a single provider's name is recorded as source
+func TestFetchWeatherSource(t *testing.T) {
+	client := New(WithProvider(&stubProvider{name: "stub", temp: 1}))
+
+	data, err := client.FetchWeather(context.Background(), Location{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if data.Source != "stub" {
+		t.Errorf("Expected source stub, got %q", data.Source)
+	}
+}
@@ -0,0 +1,79 @@
+package weathersync
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestFetchRiverDischarge tests the flood request and parsing of the
+// ensemble statistics
+func TestFetchRiverDischarge(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/flood" {
+			t.Errorf("Expected path /v1/flood, got %s", r.URL.Path)
+		}
+
+		q := r.URL.Query()
+		if q.Get("start_date") != "2024-06-01" || q.Get("end_date") != "2024-06-02" {
+			t.Errorf("Unexpected date range %s..%s", q.Get("start_date"), q.Get("end_date"))
+		}
+		if q.Get("daily") != floodVariables {
+			t.Errorf("Unexpected daily parameter %q", q.Get("daily"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"daily": {
+				"time": ["2024-06-01", "2024-06-02"],
+				"river_discharge": [412.5, 398.1],
+				"river_discharge_mean": [null, 401.7],
+				"river_discharge_median": [null, 399.0],
+				"river_discharge_min": [null, 350.2],
+				"river_discharge_max": [null, 470.9]
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := New(WithFloodURL(server.URL))
+	location := Location{Name: "Cologne", Latitude: 50.94, Longitude: 6.96}
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+
+	discharge, err := client.FetchRiverDischarge(context.Background(), location, start, end)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(discharge.Days) != 2 {
+		t.Fatalf("Expected 2 days, got %d", len(discharge.Days))
+	}
+
+	first := discharge.Days[0]
+	if !first.Date.Equal(start) || first.Discharge != 412.5 {
+		t.Errorf("Unexpected first day %+v", first)
+	}
+	if !math.IsNaN(first.Mean) {
+		t.Errorf("Expected NaN for missing ensemble mean, got %f", first.Mean)
+	}
+
+	second := discharge.Days[1]
+	if second.Mean != 401.7 || second.Median != 399 || second.Min != 350.2 || second.Max != 470.9 {
+		t.Errorf("Unexpected ensemble statistics %+v", second)
+	}
+}
+
+// TestFetchRiverDischargeInvalidRange tests that an inverted date range is rejected
+func TestFetchRiverDischargeInvalidRange(t *testing.T) {
+	client := New()
+	start := time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	_, err := client.FetchRiverDischarge(context.Background(), Location{}, start, start.AddDate(0, 0, -1))
+	if err == nil {
+		t.Fatal("Expected error for inverted date range, got nil")
+	}
+}
@@ -0,0 +1,321 @@
+package weathersync
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	// apiTimeLayout is the ISO 8601 layout (without seconds) used by Open-Meteo
+	// for hourly timestamps.
+	apiTimeLayout = "2006-01-02T15:04"
+
+	// apiDateLayout is the ISO 8601 date layout used for daily values.
+	apiDateLayout = "2006-01-02"
+)
+
+// dailyVariables is the list of Open-Meteo variables requested for daily forecasts.
+const dailyVariables = "weather_code,temperature_2m_max,temperature_2m_min,precipitation_sum,precipitation_probability_max,sunrise,sunset,uv_index_max,wind_speed_10m_max"
+
+// ForecastOptions configures forecast requests.
+type ForecastOptions struct {
+	// Days is the number of forecast days to request (1-16).
+	// Zero uses the API default of 7 days.
+	Days int
+}
+
+// HourlyPoint contains the forecast values for a single hour.
+// The fields mirror those of WeatherData. Temperatures, wind speeds and
+// precipitation are in the units of the forecast (see WithUnits). Values
+// missing from the forecast are NaN; WeatherCode is then 0.
+type HourlyPoint struct {
+	// Time is the start of the hour this point applies to (UTC)
+	Time time.Time
+
+	// Temperature is the temperature in Celsius, or in the temperature unit
+	// set with WithUnits
+	Temperature float64
+
+	// ApparentTemperature is how the temperature "feels", in the same unit
+	// as Temperature
+	ApparentTemperature float64
+
+	// Humidity is the relative humidity as a percentage (0-100)
+	Humidity float64
+
+	// Precipitation is the rainfall in millimeters, or in the precipitation
+	// unit set with WithUnits
+	Precipitation float64
+
+	// WeatherCode is the WMO weather interpretation code
+	WeatherCode int
+
+	// WindSpeed is the wind speed at 10 meters height in km/h, or in the
+	// wind speed unit set with WithUnits
+	WindSpeed float64
+
+	// WindDirection is the wind direction at 10 meters height in degrees (0-360)
+	WindDirection float64
+
+	// WindGusts is the maximum wind gust speed, in the same unit as WindSpeed
+	WindGusts float64
+
+	// CloudCover is the total cloud coverage as a percentage (0-100)
+	CloudCover float64
+
+	// Visibility is the visibility distance in meters
+	Visibility float64
+
+	// Pressure is the atmospheric pressure at mean sea level in hPa
+	Pressure float64
+}
+
+// HourlyForecast contains an hour-by-hour forecast for a location.
+type HourlyForecast struct {
+	// Location is the geographic location this forecast applies to
+	Location Location
+
+	// Hours contains one entry per forecast hour, in chronological order
+	Hours []HourlyPoint
+
+	// Units are the units of the forecast values
+	Units Units
+
+	// FetchDuration is the time it took to fetch this forecast
+	FetchDuration time.Duration
+
+	// Timestamp is when this forecast was fetched
+	Timestamp time.Time
+}
+
+// DailyPoint contains the forecast summary for a single day.
+// Temperatures, wind speeds and precipitation are in the units of the
+// forecast (see WithUnits). Values missing from the forecast, e.g.
+// beyond the horizon of a model, are NaN; WeatherCode is then 0.
+type DailyPoint struct {
+	// Date is the day this summary applies to (midnight in the forecast's
+	// timezone, see WithTimezone)
+	Date time.Time
+
+	// WeatherCode is the most severe WMO weather code of the day
+	WeatherCode int
+
+	// TemperatureMax is the maximum temperature of the day in Celsius, or in
+	// the temperature unit set with WithUnits
+	TemperatureMax float64
+
+	// TemperatureMin is the minimum temperature of the day, in the same unit
+	// as TemperatureMax
+	TemperatureMin float64
+
+	// PrecipitationSum is the total precipitation of the day in millimeters,
+	// or in the precipitation unit set with WithUnits
+	PrecipitationSum float64
+
+	// PrecipitationProbabilityMax is the highest hourly probability of
+	// precipitation as a percentage (0-100)
+	PrecipitationProbabilityMax float64
+
+	// Sunrise is the time of sunrise
+	Sunrise time.Time
+
+	// Sunset is the time of sunset
+	Sunset time.Time
+
+	// UVIndexMax is the maximum UV index of the day
+	UVIndexMax float64
+
+	// WindSpeedMax is the maximum wind speed at 10 meters height in km/h, or
+	// in the wind speed unit set with WithUnits
+	WindSpeedMax float64
+}
+
+// DailyForecast contains a day-by-day forecast summary for a location.
+type DailyForecast struct {
+	// Location is the geographic location this forecast applies to
+	Location Location
+
+	// Days contains one entry per forecast day, in chronological order
+	Days []DailyPoint
+
+	// Units are the units of the forecast values
+	Units Units
+
+	// FetchDuration is the time it took to fetch this forecast
+	FetchDuration time.Duration
+
+	// Timestamp is when this forecast was fetched
+	Timestamp time.Time
+}
+
+// forecastQuery returns the query string fragment for the given options.
+func (o ForecastOptions) forecastQuery() string {
+	if o.Days <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("&forecast_days=%d", o.Days)
+}
+
+// FetchHourlyForecast retrieves an hourly forecast for a single location.
+// The number of days covered is controlled by opts.Days.
+func (c *Client) FetchHourlyForecast(ctx context.Context, location Location, opts ForecastOptions) (*HourlyForecast, error) {
+	url := fmt.Sprintf("%s/v1/forecast?latitude=%f&longitude=%f&hourly=%s%s%s",
+		c.apiURL, location.Latitude, location.Longitude, weatherVariables, opts.forecastQuery(), c.units.params())
+
+	start := time.Now()
+
+	var apiResp struct {
+		Hourly struct {
+			Time                []string   `json:"time"`
+			Temperature2M       []*float64 `json:"temperature_2m"`
+			ApparentTemperature []*float64 `json:"apparent_temperature"`
+			RelativeHumidity2M  []*float64 `json:"relative_humidity_2m"`
+			Precipitation       []*float64 `json:"precipitation"`
+			WeatherCode         []int      `json:"weather_code"`
+			WindSpeed10M        []*float64 `json:"wind_speed_10m"`
+			WindDirection10M    []*float64 `json:"wind_direction_10m"`
+			WindGusts10M        []*float64 `json:"wind_gusts_10m"`
+			CloudCover          []*float64 `json:"cloud_cover"`
+			Visibility          []*float64 `json:"visibility"`
+			PressureMsl         []*float64 `json:"pressure_msl"`
+		} `json:"hourly"`
+	}
+
+	if err := c.getJSON(ctx, url, &apiResp); err != nil {
+		return nil, err
+	}
+
+	h := apiResp.Hourly
+	hours := make([]HourlyPoint, len(h.Time))
+	for i, ts := range h.Time {
+		t, err := time.Parse(apiTimeLayout, ts)
+		if err != nil {
+			return nil, &decodeError{fmt.Errorf("parse time %q: %w", ts, err)}
+		}
+		hours[i] = HourlyPoint{
+			Time:                t,
+			Temperature:         valueAt(h.Temperature2M, i),
+			ApparentTemperature: valueAt(h.ApparentTemperature, i),
+			Humidity:            valueAt(h.RelativeHumidity2M, i),
+			Precipitation:       valueAt(h.Precipitation, i),
+			WeatherCode:         intAt(h.WeatherCode, i),
+			WindSpeed:           valueAt(h.WindSpeed10M, i),
+			WindDirection:       valueAt(h.WindDirection10M, i),
+			WindGusts:           valueAt(h.WindGusts10M, i),
+			CloudCover:          valueAt(h.CloudCover, i),
+			Visibility:          valueAt(h.Visibility, i),
+			Pressure:            valueAt(h.PressureMsl, i),
+		}
+	}
+
+	return &HourlyForecast{
+		Location:      location,
+		Hours:         hours,
+		Units:         c.units,
+		FetchDuration: time.Since(start),
+		Timestamp:     time.Now(),
+	}, nil
+}
+
+// FetchDailyForecast retrieves a daily forecast summary for a single location,
+// including temperature extremes, precipitation, sunrise and sunset.
+// The number of days covered is controlled by opts.Days. Days run from
+// midnight to midnight in the timezone set with WithTimezone, by default
+// the location's own.
+func (c *Client) FetchDailyForecast(ctx context.Context, location Location, opts ForecastOptions) (*DailyForecast, error) {
+	url := fmt.Sprintf("%s/v1/forecast?latitude=%f&longitude=%f&daily=%s%s%s%s",
+		c.apiURL, location.Latitude, location.Longitude, dailyVariables, opts.forecastQuery(),
+		c.units.params(), timezoneParam(c.timezone))
+
+	start := time.Now()
+
+	var apiResp struct {
+		responseTimezone
+		Daily struct {
+			Time                        []string   `json:"time"`
+			WeatherCode                 []int      `json:"weather_code"`
+			Temperature2MMax            []*float64 `json:"temperature_2m_max"`
+			Temperature2MMin            []*float64 `json:"temperature_2m_min"`
+			PrecipitationSum            []*float64 `json:"precipitation_sum"`
+			PrecipitationProbabilityMax []*float64 `json:"precipitation_probability_max"`
+			Sunrise                     []string   `json:"sunrise"`
+			Sunset                      []string   `json:"sunset"`
+			UVIndexMax                  []*float64 `json:"uv_index_max"`
+			WindSpeed10MMax             []*float64 `json:"wind_speed_10m_max"`
+		} `json:"daily"`
+	}
+
+	if err := c.getJSON(ctx, url, &apiResp); err != nil {
+		return nil, err
+	}
+
+	d := apiResp.Daily
+	loc := apiResp.timeLocation()
+	days := make([]DailyPoint, len(d.Time))
+	for i, ds := range d.Time {
+		date, err := time.ParseInLocation(apiDateLayout, ds, loc)
+		if err != nil {
+			return nil, &decodeError{fmt.Errorf("parse date %q: %w", ds, err)}
+		}
+		sunrise, err := timeAt(d.Sunrise, i, loc)
+		if err != nil {
+			return nil, err
+		}
+		sunset, err := timeAt(d.Sunset, i, loc)
+		if err != nil {
+			return nil, err
+		}
+		days[i] = DailyPoint{
+			Date:                        date,
+			WeatherCode:                 intAt(d.WeatherCode, i),
+			TemperatureMax:              valueAt(d.Temperature2MMax, i),
+			TemperatureMin:              valueAt(d.Temperature2MMin, i),
+			PrecipitationSum:            valueAt(d.PrecipitationSum, i),
+			PrecipitationProbabilityMax: valueAt(d.PrecipitationProbabilityMax, i),
+			Sunrise:                     sunrise,
+			Sunset:                      sunset,
+			UVIndexMax:                  valueAt(d.UVIndexMax, i),
+			WindSpeedMax:                valueAt(d.WindSpeed10MMax, i),
+		}
+	}
+
+	return &DailyForecast{
+		Location:      location,
+		Days:          days,
+		Units:         c.units,
+		FetchDuration: time.Since(start),
+		Timestamp:     time.Now(),
+	}, nil
+}
+
+// timeAt parses s[i] as an API timestamp local to loc. Missing or empty
+// entries (such as polar day or night for sunrise/sunset) yield the zero time.
+func timeAt(s []string, i int, loc *time.Location) (time.Time, error) {
+	if i >= len(s) || s[i] == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.ParseInLocation(apiTimeLayout, s[i], loc)
+	if err != nil {
+		return time.Time{}, &decodeError{fmt.Errorf("parse time %q: %w", s[i], err)}
+	}
+	return t, nil
+}
+
+// valueAt returns s[i], or NaN if the value is null or the series is
+// shorter than expected.
+func valueAt(s []*float64, i int) float64 {
+	if i < len(s) {
+		return valueOrNaN(s[i])
+	}
+	return math.NaN()
+}
+
+// intAt returns s[i], or zero if the series is shorter than expected.
+func intAt(s []int, i int) int {
+	if i < len(s) {
+		return s[i]
+	}
+	return 0
+}
@@ -0,0 +1,195 @@
+package weathersync
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestFetchHourlyForecastSuccess tests parsing of an hourly forecast response
+func TestFetchHourlyForecastSuccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("hourly") == "" {
+			t.Error("Missing hourly parameter")
+		}
+		if days := r.URL.Query().Get("forecast_days"); days != "2" {
+			t.Errorf("Expected forecast_days=2, got %q", days)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"hourly": {
+				"time": ["2024-06-01T00:00", "2024-06-01T01:00"],
+				"temperature_2m": [12.5, 11.8],
+				"apparent_temperature": [null, 10.9],
+				"weather_code": [3, 61],
+				"wind_speed_10m": [8.2, 9.1]
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL))
+	location := Location{Name: "Berlin", Latitude: 52.52, Longitude: 13.41}
+
+	forecast, err := client.FetchHourlyForecast(context.Background(), location, ForecastOptions{Days: 2})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if forecast.Location.Name != "Berlin" {
+		t.Errorf("Expected location Berlin, got %s", forecast.Location.Name)
+	}
+
+	if len(forecast.Hours) != 2 {
+		t.Fatalf("Expected 2 hours, got %d", len(forecast.Hours))
+	}
+
+	second := forecast.Hours[1]
+	want := time.Date(2024, 6, 1, 1, 0, 0, 0, time.UTC)
+	if !second.Time.Equal(want) {
+		t.Errorf("Expected time %v, got %v", want, second.Time)
+	}
+
+	if second.Temperature != 11.8 {
+		t.Errorf("Expected temperature 11.8, got %f", second.Temperature)
+	}
+
+	if second.WeatherCode != 61 {
+		t.Errorf("Expected weather code 61, got %d", second.WeatherCode)
+	}
+
+	// Variables missing from the response or null are NaN
+	if !math.IsNaN(second.Humidity) {
+		t.Errorf("Expected humidity NaN, got %f", second.Humidity)
+	}
+
+	if !math.IsNaN(forecast.Hours[0].ApparentTemperature) || second.ApparentTemperature != 10.9 {
+		t.Errorf("Expected apparent temperature NaN/10.9, got %f/%f",
+			forecast.Hours[0].ApparentTemperature, second.ApparentTemperature)
+	}
+}
+
+// TestFetchHourlyForecastAPIError tests handling of API errors
+func TestFetchHourlyForecastAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL))
+
+	_, err := client.FetchHourlyForecast(context.Background(), Location{Name: "Test"}, ForecastOptions{})
+	if err == nil {
+		t.Fatal("Expected error for API status 400, got nil")
+	}
+}
+
+// TestFetchDailyForecastSuccess tests parsing of a daily forecast response
+func TestFetchDailyForecastSuccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("daily") == "" {
+			t.Error("Missing daily parameter")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"daily": {
+				"time": ["2024-06-01", "2024-06-02"],
+				"weather_code": [3, 80],
+				"temperature_2m_max": [21.4, 18.9],
+				"temperature_2m_min": [11.2, 10.5],
+				"precipitation_sum": [0.0, 6.3],
+				"precipitation_probability_max": [40, null],
+				"sunrise": ["2024-06-01T02:47", "2024-06-02T02:46"],
+				"sunset": ["2024-06-01T19:20", "2024-06-02T19:21"]
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL))
+	location := Location{Name: "Berlin", Latitude: 52.52, Longitude: 13.41}
+
+	forecast, err := client.FetchDailyForecast(context.Background(), location, ForecastOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(forecast.Days) != 2 {
+		t.Fatalf("Expected 2 days, got %d", len(forecast.Days))
+	}
+
+	day := forecast.Days[1]
+	if !day.Date.Equal(time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected date %v", day.Date)
+	}
+
+	if day.TemperatureMax != 18.9 || day.TemperatureMin != 10.5 {
+		t.Errorf("Expected max/min 18.9/10.5, got %f/%f", day.TemperatureMax, day.TemperatureMin)
+	}
+
+	if day.PrecipitationSum != 6.3 {
+		t.Errorf("Expected precipitation sum 6.3, got %f", day.PrecipitationSum)
+	}
+
+	if !day.Sunset.Equal(time.Date(2024, 6, 2, 19, 21, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected sunset %v", day.Sunset)
+	}
+
+	if !math.IsNaN(day.UVIndexMax) {
+		t.Errorf("Expected missing UV index to be NaN, got %f", day.UVIndexMax)
+	}
+
+	if forecast.Days[0].PrecipitationProbabilityMax != 40 || !math.IsNaN(day.PrecipitationProbabilityMax) {
+		t.Errorf("Expected precipitation probability 40/NaN, got %f/%f",
+			forecast.Days[0].PrecipitationProbabilityMax, day.PrecipitationProbabilityMax)
+	}
+}
+
+// TestFetchDailyForecastTimezone tests that days are requested and parsed in
+// the location's timezone
+func TestFetchDailyForecastTimezone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tz := r.URL.Query().Get("timezone"); tz != "auto" {
+			t.Errorf("Expected timezone=auto, got %q", tz)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"timezone": "Asia/Tokyo",
+			"timezone_abbreviation": "JST",
+			"utc_offset_seconds": 32400,
+			"daily": {
+				"time": ["2024-06-02"],
+				"temperature_2m_max": [27.1],
+				"sunrise": ["2024-06-02T04:25"]
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL))
+	location := Location{Name: "Tokyo", Latitude: 35.68, Longitude: 139.69}
+
+	forecast, err := client.FetchDailyForecast(context.Background(), location, ForecastOptions{Days: 1})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(forecast.Days) != 1 {
+		t.Fatalf("Expected 1 day, got %d", len(forecast.Days))
+	}
+
+	day := forecast.Days[0]
+	if !day.Date.Equal(time.Date(2024, 6, 1, 15, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected local midnight (15:00 UTC the day before), got %v", day.Date)
+	}
+
+	if !day.Sunrise.Equal(time.Date(2024, 6, 1, 19, 25, 0, 0, time.UTC)) {
+		t.Errorf("Expected sunrise at 04:25 JST, got %v", day.Sunrise)
+	}
+}
@@ -0,0 +1,129 @@
+package weathersync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// GeocodeOptions configures geocoding requests.
+type GeocodeOptions struct {
+	// Count is the maximum number of candidates to return (1-100).
+	// Zero uses the API default of 10.
+	Count int
+
+	// Language is the language of the returned names as an ISO 639-1 code,
+	// e.g. "de". Empty uses English.
+	Language string
+
+	// CountryCode restricts results to a country given as an ISO 3166-1
+	// alpha-2 code, e.g. "US". Empty searches worldwide.
+	CountryCode string
+}
+
+// Place is a geocoding candidate: a Location with its country, admin region,
+// timezone and elevation filled in, plus further details about the place.
+type Place struct {
+	Location
+
+	// CountryCode is the ISO 3166-1 alpha-2 code of the country, e.g. "DE"
+	CountryCode string
+
+	// Population is the number of inhabitants, or zero if unknown
+	Population int
+}
+
+// WithGeocodingURL sets a custom geocoding API URL.
+// Default is "https://geocoding-api.open-meteo.com".
+func WithGeocodingURL(url string) Option {
+	return func(c *Client) {
+		c.geocodingURL = url
+	}
+}
+
+// Geocode searches places by name (a place name or postal code) with the
+// Open-Meteo geocoding API. Candidates are ordered by relevance, which
+// favours larger places. An empty slice means no place matched.
+func (c *Client) Geocode(ctx context.Context, name string, opts GeocodeOptions) ([]Place, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("geocode: empty name")
+	}
+
+	query := url.Values{}
+	query.Set("name", name)
+	query.Set("format", "json")
+	if opts.Count > 0 {
+		query.Set("count", fmt.Sprint(opts.Count))
+	}
+	if opts.Language != "" {
+		query.Set("language", opts.Language)
+	}
+	if opts.CountryCode != "" {
+		query.Set("countryCode", opts.CountryCode)
+	}
+
+	var apiResp struct {
+		Results []struct {
+			Name        string  `json:"name"`
+			Latitude    float64 `json:"latitude"`
+			Longitude   float64 `json:"longitude"`
+			Elevation   float64 `json:"elevation"`
+			Timezone    string  `json:"timezone"`
+			Country     string  `json:"country"`
+			CountryCode string  `json:"country_code"`
+			Admin1      string  `json:"admin1"`
+			Population  int     `json:"population"`
+		} `json:"results"`
+	}
+
+	if err := c.getJSON(ctx, c.geocodingURL+"/v1/search?"+query.Encode(), &apiResp); err != nil {
+		return nil, err
+	}
+
+	places := make([]Place, len(apiResp.Results))
+	for i, r := range apiResp.Results {
+		places[i] = Place{
+			Location: Location{
+				Name:      r.Name,
+				Latitude:  r.Latitude,
+				Longitude: r.Longitude,
+				Timezone:  r.Timezone,
+				Country:   r.Country,
+				Region:    r.Admin1,
+				Elevation: r.Elevation,
+			},
+			CountryCode: r.CountryCode,
+			Population:  r.Population,
+		}
+	}
+
+	return places, nil
+}
+
+// FetchWeatherByName geocodes name and fetches current weather for the best
+// matching place, like FetchWeather. It returns an error matching
+// ErrPlaceNotFound if no place matches.
+func (c *Client) FetchWeatherByName(ctx context.Context, name string, opts ...FetchOption) (*WeatherData, error) {
+	start := time.Now()
+
+	places, err := c.Geocode(ctx, name, GeocodeOptions{Count: 1})
+	if err != nil {
+		return nil, fmt.Errorf("geocode %q: %w", name, err)
+	}
+	if len(places) == 0 {
+		return nil, fmt.Errorf("geocode %q: %w", name, ErrPlaceNotFound)
+	}
+
+	data, err := c.FetchWeather(ctx, places[0].Location, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Include the geocoding request
+	data.FetchDuration = time.Since(start)
+	return data, nil
+}
@@ -0,0 +1,125 @@
+package weathersync
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestGeocode tests the geocoding request and parsing of candidates
+func TestGeocode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/search" {
+			t.Errorf("Expected path /v1/search, got %s", r.URL.Path)
+		}
+
+		q := r.URL.Query()
+		if q.Get("name") != "Springfield" || q.Get("count") != "2" || q.Get("countryCode") != "US" {
+			t.Errorf("Unexpected query %q", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"results": [
+				{
+					"id": 4250542,
+					"name": "Springfield",
+					"latitude": 39.80172,
+					"longitude": -89.64371,
+					"elevation": 182.0,
+					"timezone": "America/Chicago",
+					"population": 116565,
+					"country_code": "US",
+					"country": "United States",
+					"admin1": "Illinois"
+				},
+				{
+					"id": 4409896,
+					"name": "Springfield",
+					"latitude": 37.21533,
+					"longitude": -93.29824,
+					"elevation": 396.0,
+					"timezone": "America/Chicago",
+					"country_code": "US",
+					"country": "United States",
+					"admin1": "Missouri"
+				}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := New(WithGeocodingURL(server.URL))
+
+	places, err := client.Geocode(context.Background(), "Springfield", GeocodeOptions{Count: 2, CountryCode: "US"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(places) != 2 {
+		t.Fatalf("Expected 2 places, got %d", len(places))
+	}
+
+	first := places[0]
+	if first.Name != "Springfield" || first.Region != "Illinois" || first.Country != "United States" {
+		t.Errorf("Unexpected place %+v", first)
+	}
+	if first.Latitude != 39.80172 || first.Timezone != "America/Chicago" {
+		t.Errorf("Unexpected coordinates or timezone %+v", first.Location)
+	}
+	if first.Population != 116565 || first.Elevation != 182 || first.CountryCode != "US" {
+		t.Errorf("Unexpected details %+v", first)
+	}
+
+	if places[1].Population != 0 {
+		t.Errorf("Expected unknown population to be 0, got %d", places[1].Population)
+	}
+}
+
+// TestFetchWeatherByName tests that the best match is fetched
+func TestFetchWeatherByName(t *testing.T) {
+	geocoder := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"results": [{"name": "Berlin", "latitude": 52.52, "longitude": 13.41, "country": "Germany", "admin1": "Land Berlin"}]}`))
+	}))
+	defer geocoder.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if lat := r.URL.Query().Get("latitude"); lat != "52.520000" {
+			t.Errorf("Expected latitude 52.520000, got %q", lat)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"current": {"temperature_2m": 17.4}}`))
+	}))
+	defer api.Close()
+
+	client := New(WithAPIURL(api.URL), WithGeocodingURL(geocoder.URL))
+
+	data, err := client.FetchWeatherByName(context.Background(), "Berlin")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if data.Temperature != 17.4 || data.Location.Name != "Berlin" || data.Location.Country != "Germany" {
+		t.Errorf("Unexpected data %+v", data)
+	}
+}
+
+// TestFetchWeatherByNameNotFound tests the error for names without a match
+func TestFetchWeatherByNameNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"generationtime_ms": 0.5}`))
+	}))
+	defer server.Close()
+
+	client := New(WithGeocodingURL(server.URL))
+
+	_, err := client.FetchWeatherByName(context.Background(), "Nowhereville")
+	if !errors.Is(err, ErrPlaceNotFound) {
+		t.Fatalf("Expected ErrPlaceNotFound, got %v", err)
+	}
+}
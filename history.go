@@ -0,0 +1,180 @@
+package weathersync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// HistoryVariables selects which Open-Meteo variables to request from the
+// archive API. Variable names are passed through verbatim, e.g.
+// "temperature_2m" for hourly or "temperature_2m_max" for daily series.
+// If both lists are empty, the hourly variables of WeatherData are requested.
+type HistoryVariables struct {
+	// Hourly lists the hourly variables to request
+	Hourly []string
+
+	// Daily lists the daily aggregations to request
+	Daily []string
+}
+
+// Series is a set of time-aligned values returned by the API.
+type Series struct {
+	// Time contains the timestamp of each entry. Daily entries are at
+	// midnight in the timezone of the response (see WithTimezone).
+	Time []time.Time
+
+	// Values maps each numeric variable name to one value per entry in Time.
+	// Values missing in the archive are reported as NaN.
+	// Non-numeric variables (such as sunrise and sunset) are not included.
+	Values map[string][]float64
+}
+
+// History contains historical weather series for a location.
+type History struct {
+	// Location is the geographic location this history applies to
+	Location Location
+
+	// Start and End are the first and last day of the requested range
+	Start time.Time
+	End   time.Time
+
+	// Hourly contains the hourly series, or nil if none were requested
+	Hourly *Series
+
+	// Daily contains the daily series, or nil if none were requested
+	Daily *Series
+
+	// Units are the units of temperature, wind speed and precipitation
+	// values (see WithUnits)
+	Units Units
+
+	// FetchDuration is the time it took to fetch this history
+	FetchDuration time.Duration
+
+	// Timestamp is when this history was fetched
+	Timestamp time.Time
+}
+
+// FetchHistory retrieves past weather for a single location between the
+// start and end dates (inclusive) from the Open-Meteo archive API.
+// Only the date part of start and end is used. Daily values are aggregated
+// over days in the timezone set with WithTimezone, by default the
+// location's own.
+func (c *Client) FetchHistory(ctx context.Context, location Location, start, end time.Time, variables HistoryVariables) (*History, error) {
+	if end.Before(start) {
+		return nil, errors.New("end date is before start date")
+	}
+
+	if len(variables.Hourly) == 0 && len(variables.Daily) == 0 {
+		variables.Hourly = strings.Split(weatherVariables, ",")
+	}
+
+	url := fmt.Sprintf("%s/v1/archive?latitude=%f&longitude=%f&start_date=%s&end_date=%s",
+		c.archiveURL, location.Latitude, location.Longitude,
+		start.Format(apiDateLayout), end.Format(apiDateLayout))
+	if len(variables.Hourly) > 0 {
+		url += "&hourly=" + strings.Join(variables.Hourly, ",")
+	}
+	if len(variables.Daily) > 0 {
+		url += "&daily=" + strings.Join(variables.Daily, ",")
+	}
+	url += c.units.params() + timezoneParam(c.timezone)
+
+	fetchStart := time.Now()
+
+	var apiResp struct {
+		responseTimezone
+		Hourly map[string]json.RawMessage `json:"hourly"`
+		Daily  map[string]json.RawMessage `json:"daily"`
+	}
+
+	if err := c.getJSON(ctx, url, &apiResp); err != nil {
+		return nil, err
+	}
+
+	history := &History{
+		Location: location,
+		Start:    start,
+		End:      end,
+		Units:    c.units,
+	}
+
+	var err error
+	loc := apiResp.timeLocation()
+	if apiResp.Hourly != nil {
+		if history.Hourly, err = parseSeries(apiResp.Hourly, apiTimeLayout, loc); err != nil {
+			return nil, fmt.Errorf("hourly: %w", err)
+		}
+	}
+	if apiResp.Daily != nil {
+		if history.Daily, err = parseSeries(apiResp.Daily, apiDateLayout, loc); err != nil {
+			return nil, fmt.Errorf("daily: %w", err)
+		}
+	}
+
+	history.FetchDuration = time.Since(fetchStart)
+	history.Timestamp = time.Now()
+
+	return history, nil
+}
+
+// at returns the value of variable name at index i, or NaN if the series
+// has no such value.
+func (s *Series) at(name string, i int) float64 {
+	if values, ok := s.Values[name]; ok && i < len(values) {
+		return values[i]
+	}
+	return math.NaN()
+}
+
+// parseSeries converts a column-oriented API block into a Series.
+// The "time" column is parsed with layout as local times in loc; all other
+// numeric columns become values.
+func parseSeries(raw map[string]json.RawMessage, layout string, loc *time.Location) (*Series, error) {
+	var times []string
+	if err := json.Unmarshal(raw["time"], &times); err != nil {
+		return nil, &decodeError{fmt.Errorf("time: %w", err)}
+	}
+
+	series := &Series{
+		Time:   make([]time.Time, len(times)),
+		Values: make(map[string][]float64, len(raw)-1),
+	}
+
+	for i, ts := range times {
+		t, err := time.ParseInLocation(layout, ts, loc)
+		if err != nil {
+			return nil, &decodeError{fmt.Errorf("parse time %q: %w", ts, err)}
+		}
+		series.Time[i] = t
+	}
+
+	for name, data := range raw {
+		if name == "time" {
+			continue
+		}
+
+		var values []*float64
+		if err := json.Unmarshal(data, &values); err != nil {
+			// Not a numeric series (e.g. sunrise/sunset)
+			continue
+		}
+
+		column := make([]float64, len(values))
+		for i, v := range values {
+			if v == nil {
+				column[i] = math.NaN()
+				continue
+			}
+			column[i] = *v
+		}
+		series.Values[name] = column
+	}
+
+	return series, nil
+}
@@ -0,0 +1,91 @@
+package weathersync
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestFetchHistorySuccess tests parsing of an archive response
+func TestFetchHistorySuccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/archive" {
+			t.Errorf("Expected path /v1/archive, got %s", r.URL.Path)
+		}
+
+		q := r.URL.Query()
+		if q.Get("start_date") != "2023-01-01" || q.Get("end_date") != "2023-01-02" {
+			t.Errorf("Unexpected date range %s..%s", q.Get("start_date"), q.Get("end_date"))
+		}
+		if q.Get("daily") != "temperature_2m_max,sunrise" {
+			t.Errorf("Unexpected daily parameter %q", q.Get("daily"))
+		}
+		if q.Get("timezone") != "auto" {
+			t.Errorf("Expected timezone=auto, got %q", q.Get("timezone"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"timezone": "Europe/Berlin",
+			"timezone_abbreviation": "CET",
+			"utc_offset_seconds": 3600,
+			"daily": {
+				"time": ["2023-01-01", "2023-01-02"],
+				"temperature_2m_max": [4.2, null],
+				"sunrise": ["2023-01-01T07:17", "2023-01-02T07:17"]
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := New(WithArchiveURL(server.URL))
+	location := Location{Name: "Berlin", Latitude: 52.52, Longitude: 13.41}
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	history, err := client.FetchHistory(context.Background(), location, start, end,
+		HistoryVariables{Daily: []string{"temperature_2m_max", "sunrise"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if history.Hourly != nil {
+		t.Error("Expected no hourly series")
+	}
+
+	if history.Daily == nil || len(history.Daily.Time) != 2 {
+		t.Fatalf("Expected 2 daily entries, got %+v", history.Daily)
+	}
+
+	if !history.Daily.Time[1].Equal(time.Date(2023, 1, 1, 23, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected local midnight, got %v", history.Daily.Time[1])
+	}
+
+	maxTemps := history.Daily.Values["temperature_2m_max"]
+	if len(maxTemps) != 2 || maxTemps[0] != 4.2 {
+		t.Errorf("Unexpected temperature_2m_max values %v", maxTemps)
+	}
+
+	if !math.IsNaN(maxTemps[1]) {
+		t.Errorf("Expected NaN for missing value, got %f", maxTemps[1])
+	}
+
+	if _, ok := history.Daily.Values["sunrise"]; ok {
+		t.Error("Expected non-numeric series to be skipped")
+	}
+}
+
+// TestFetchHistoryInvalidRange tests that an inverted date range is rejected
+func TestFetchHistoryInvalidRange(t *testing.T) {
+	client := New()
+	start := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, -1)
+
+	_, err := client.FetchHistory(context.Background(), Location{}, start, end, HistoryVariables{})
+	if err == nil {
+		t.Fatal("Expected error for inverted date range, got nil")
+	}
+}
@@ -0,0 +1,209 @@
+package weathersync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// marineVariables is the list of Open-Meteo marine variables requested for
+// both current conditions and the hourly forecast.
+const marineVariables = "wave_height,wave_direction,wave_period,swell_wave_height,swell_wave_direction,swell_wave_period,wind_wave_height,wind_wave_direction,wind_wave_period"
+
+// MarineConditions contains sea state values. Heights are in meters,
+// directions in degrees (0-360, the direction the waves come from) and
+// periods in seconds. Values the API did not report are NaN.
+type MarineConditions struct {
+	// WaveHeight is the significant height of the combined wind and swell waves
+	WaveHeight float64
+
+	// WaveDirection is the mean direction of the combined waves
+	WaveDirection float64
+
+	// WavePeriod is the mean period of the combined waves
+	WavePeriod float64
+
+	// SwellWaveHeight is the significant height of the swell waves
+	SwellWaveHeight float64
+
+	// SwellWaveDirection is the mean direction of the swell waves
+	SwellWaveDirection float64
+
+	// SwellWavePeriod is the mean period of the swell waves
+	SwellWavePeriod float64
+
+	// WindWaveHeight is the significant height of the wind waves
+	WindWaveHeight float64
+
+	// WindWaveDirection is the mean direction of the wind waves
+	WindWaveDirection float64
+
+	// WindWavePeriod is the mean period of the wind waves
+	WindWavePeriod float64
+}
+
+// MarineHour contains the marine forecast for a single hour. Values missing
+// from the forecast are reported as NaN.
+type MarineHour struct {
+	// Time is the start of the hour this point applies to, in the timezone
+	// set with WithTimezone
+	Time time.Time
+
+	MarineConditions
+}
+
+// MarineData contains current sea state and an hourly marine forecast for
+// a location.
+type MarineData struct {
+	// Location is the geographic location this data applies to
+	Location Location
+
+	// Current contains the current sea state
+	Current MarineConditions
+
+	// ObservationTime is the time Current refers to (see WithTimezone)
+	ObservationTime time.Time
+
+	// Hours contains one entry per forecast hour, in chronological order
+	Hours []MarineHour
+
+	// FetchDuration is the time it took to fetch this data
+	FetchDuration time.Duration
+
+	// Timestamp is when this data was fetched
+	Timestamp time.Time
+
+	// Attempts is the number of HTTP requests made to fetch this data,
+	// including retries (see WithRetry)
+	Attempts int
+}
+
+// NoMarineDataError is returned by FetchMarine when the marine API has no
+// data for a location, typically because it is on land or too far inland.
+// It matches ErrNoMarineData. Transport and other API failures are reported
+// with their usual errors instead.
+type NoMarineDataError struct {
+	// Location is the location that has no marine data
+	Location Location
+
+	// Reason is the explanation sent by the API, if any
+	Reason string
+}
+
+func (e *NoMarineDataError) Error() string {
+	msg := fmt.Sprintf("no marine data for %s (%.4f, %.4f)",
+		e.Location.Name, e.Location.Latitude, e.Location.Longitude)
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg
+}
+
+func (e *NoMarineDataError) Is(target error) bool {
+	return target == ErrNoMarineData
+}
+
+// WithMarineURL sets a custom marine weather API URL.
+// Default is "https://marine-api.open-meteo.com".
+func WithMarineURL(url string) Option {
+	return func(c *Client) {
+		c.marineURL = url
+	}
+}
+
+// FetchMarine retrieves current sea state and the hourly marine forecast
+// (7 days) for a location from the Open-Meteo marine API. It returns a
+// *NoMarineDataError if the API has no data for the location, e.g. because
+// it is on land.
+func (c *Client) FetchMarine(ctx context.Context, location Location) (*MarineData, error) {
+	location = c.autoName(ctx, location)
+
+	url := fmt.Sprintf("%s/v1/marine?latitude=%f&longitude=%f&current=%s&hourly=%s%s",
+		c.marineURL, location.Latitude, location.Longitude,
+		marineVariables, marineVariables, timezoneParam(c.timezone))
+
+	start := time.Now()
+
+	var apiResp struct {
+		currentResponse
+		Hourly map[string]json.RawMessage `json:"hourly"`
+	}
+	attempts, err := c.fetchJSON(ctx, url, &apiResp)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest &&
+			strings.Contains(strings.ToLower(apiErr.Reason), "no data") {
+			return nil, &NoMarineDataError{Location: location, Reason: apiErr.Reason}
+		}
+		return nil, err
+	}
+
+	// Grid cells on land are answered with null values
+	if _, ok := apiResp.value("wave_height"); !ok {
+		return nil, &NoMarineDataError{Location: location}
+	}
+
+	observed, err := apiResp.observationTime()
+	if err != nil {
+		return nil, err
+	}
+
+	apiResp.fillTimezone(&location, c.timezone)
+
+	data := &MarineData{
+		Location:        location,
+		Current:         apiResp.marineConditions(),
+		ObservationTime: observed,
+		Attempts:        attempts,
+	}
+
+	if apiResp.Hourly != nil {
+		series, err := parseSeries(apiResp.Hourly, apiTimeLayout, apiResp.timeLocation())
+		if err != nil {
+			return nil, fmt.Errorf("hourly: %w", err)
+		}
+
+		data.Hours = make([]MarineHour, len(series.Time))
+		for i, t := range series.Time {
+			data.Hours[i] = MarineHour{
+				Time: t,
+				MarineConditions: MarineConditions{
+					WaveHeight:         series.at("wave_height", i),
+					WaveDirection:      series.at("wave_direction", i),
+					WavePeriod:         series.at("wave_period", i),
+					SwellWaveHeight:    series.at("swell_wave_height", i),
+					SwellWaveDirection: series.at("swell_wave_direction", i),
+					SwellWavePeriod:    series.at("swell_wave_period", i),
+					WindWaveHeight:     series.at("wind_wave_height", i),
+					WindWaveDirection:  series.at("wind_wave_direction", i),
+					WindWavePeriod:     series.at("wind_wave_period", i),
+				},
+			}
+		}
+	}
+
+	data.FetchDuration = time.Since(start)
+	data.Timestamp = time.Now()
+
+	return data, nil
+}
+
+// marineConditions converts the current block of a marine response.
+// Missing values are NaN, as in the hourly forecast.
+func (r *currentResponse) marineConditions() MarineConditions {
+	return MarineConditions{
+		WaveHeight:         r.float("wave_height"),
+		WaveDirection:      r.float("wave_direction"),
+		WavePeriod:         r.float("wave_period"),
+		SwellWaveHeight:    r.float("swell_wave_height"),
+		SwellWaveDirection: r.float("swell_wave_direction"),
+		SwellWavePeriod:    r.float("swell_wave_period"),
+		WindWaveHeight:     r.float("wind_wave_height"),
+		WindWaveDirection:  r.float("wind_wave_direction"),
+		WindWavePeriod:     r.float("wind_wave_period"),
+	}
+}
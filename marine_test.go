@@ -0,0 +1,116 @@
+package weathersync
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestFetchMarine tests parsing of current and hourly marine data
+func TestFetchMarine(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/marine" {
+			t.Errorf("Expected path /v1/marine, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("hourly") == "" {
+			t.Error("Missing hourly parameter")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"timezone": "Europe/Oslo",
+			"utc_offset_seconds": 7200,
+			"current": {
+				"time": "2024-06-01T12:00",
+				"wave_height": 1.42,
+				"wave_direction": 250,
+				"wave_period": 6.3,
+				"swell_wave_height": 0.9,
+				"wind_wave_height": 0.8
+			},
+			"hourly": {
+				"time": ["2024-06-01T00:00", "2024-06-01T01:00"],
+				"wave_height": [1.2, null],
+				"swell_wave_period": [8.1, 8.3]
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := New(WithMarineURL(server.URL))
+
+	data, err := client.FetchMarine(context.Background(), Location{Name: "Skagerrak", Latitude: 58.5, Longitude: 9.5})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if data.Current.WaveHeight != 1.42 || data.Current.WaveDirection != 250 || data.Current.SwellWaveHeight != 0.9 {
+		t.Errorf("Unexpected current conditions %+v", data.Current)
+	}
+
+	if !math.IsNaN(data.Current.WindWavePeriod) {
+		t.Errorf("Expected NaN for missing current wind wave period, got %f", data.Current.WindWavePeriod)
+	}
+
+	if len(data.Hours) != 2 {
+		t.Fatalf("Expected 2 hours, got %d", len(data.Hours))
+	}
+
+	want := time.Date(2024, 5, 31, 23, 0, 0, 0, time.UTC)
+	if !data.Hours[1].Time.Equal(want) {
+		t.Errorf("Expected second hour %v, got %v", want, data.Hours[1].Time)
+	}
+	if data.Hours[0].WaveHeight != 1.2 || data.Hours[1].SwellWavePeriod != 8.3 {
+		t.Errorf("Unexpected hourly values %+v", data.Hours)
+	}
+	if !math.IsNaN(data.Hours[1].WaveHeight) || !math.IsNaN(data.Hours[0].WindWavePeriod) {
+		t.Errorf("Expected NaN for missing hourly values, got %+v", data.Hours)
+	}
+}
+
+// TestFetchMarineNoData tests that land locations are reported with
+// NoMarineDataError, whether the API answers with nulls or an error
+func TestFetchMarineNoData(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr bool
+	}{
+		{"null values", http.StatusOK, `{"current": {"time": "2024-06-01T12:00", "wave_height": null}}`, true},
+		{"error response", http.StatusBadRequest, `{"error": true, "reason": "No data is available for this location"}`, true},
+		{"other error", http.StatusBadRequest, `{"error": true, "reason": "Cannot initialize WeatherVariable from invalid String value foo"}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := New(WithMarineURL(server.URL))
+
+			_, err := client.FetchMarine(context.Background(), Location{Name: "Madrid", Latitude: 40.42, Longitude: -3.7})
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+
+			var noData *NoMarineDataError
+			if got := errors.As(err, &noData); got != tt.wantErr {
+				t.Fatalf("Expected NoMarineDataError %v, got %v (%v)", tt.wantErr, got, err)
+			}
+			if errors.Is(err, ErrNoMarineData) != tt.wantErr {
+				t.Errorf("Expected errors.Is(ErrNoMarineData) %v for %v", tt.wantErr, err)
+			}
+			if tt.wantErr && noData.Location.Name != "Madrid" {
+				t.Errorf("Expected location Madrid, got %q", noData.Location.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package weathersync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// METNorway is the Provider for the Norwegian Meteorological Institute's
+// Locationforecast 2.0 API (https://api.met.no/weatherapi/locationforecast/2.0/documentation).
+//
+// MET Norway's terms of service require every request to carry a
+// User-Agent identifying the application and a way to contact its owner,
+// e.g. "acme-dispatch/1.0 ops@acme.example".
+type METNorway struct {
+	apiURL    string
+	userAgent string
+	client    *Client
+}
+
+// NewMETNorway creates a MET Norway provider using apiURL as base URL
+// (default "https://api.met.no" if empty) and the given identifying User-Agent.
+func NewMETNorway(apiURL, userAgent string) *METNorway {
+	if apiURL == "" {
+		apiURL = "https://api.met.no"
+	}
+	return &METNorway{
+		apiURL:    apiURL,
+		userAgent: userAgent,
+	}
+}
+
+// Name implements Provider.
+func (p *METNorway) Name() string {
+	return "met-norway"
+}
+
+func (p *METNorway) bind(c *Client) {
+	p.client = c
+}
+
+// Current implements Provider using the compact Locationforecast product.
+// Instant values come from the forecast step closest to now; precipitation
+// and the weather code come from its next_1_hours summary. MET Norway does
+// not provide apparent temperature, wind gusts or visibility in the compact
+// product, so these fields are NaN, as are values missing from the step.
+func (p *METNorway) Current(ctx context.Context, location Location) (*WeatherData, error) {
+	if p.client == nil {
+		return nil, unboundError(p.Name())
+	}
+	if p.userAgent == "" {
+		return nil, errors.New("met-norway: an identifying User-Agent is required")
+	}
+
+	// MET Norway asks for at most 4 decimals to keep its caches effective
+	url := fmt.Sprintf("%s/weatherapi/locationforecast/2.0/compact?lat=%.4f&lon=%.4f",
+		p.apiURL, location.Latitude, location.Longitude)
+
+	header := http.Header{}
+	header.Set("User-Agent", p.userAgent)
+
+	start := time.Now()
+
+	var apiResp metNorwayResponse
+	attempts, err := p.client.fetchJSONWithHeader(ctx, url, header, &apiResp)
+	if err != nil {
+		return nil, err
+	}
+
+	step, err := apiResp.current(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	instant := step.Data.Instant.Details
+	return &WeatherData{
+		Location:            location,
+		Temperature:         valueOrNaN(instant.AirTemperature),
+		ApparentTemperature: math.NaN(),
+		Humidity:            valueOrNaN(instant.RelativeHumidity),
+		Precipitation:       valueOrNaN(step.Data.Next1Hours.Details.PrecipitationAmount),
+		WeatherCode:         metNorwaySymbolToWMO(step.Data.Next1Hours.Summary.SymbolCode),
+		WindSpeed:           valueOrNaN(instant.WindSpeed) * 3.6, // m/s to km/h
+		WindDirection:       valueOrNaN(instant.WindFromDirection),
+		WindGusts:           math.NaN(),
+		CloudCover:          valueOrNaN(instant.CloudAreaFraction),
+		Visibility:          math.NaN(),
+		Pressure:            valueOrNaN(instant.AirPressureAtSeaLevel),
+		FetchDuration:       time.Since(start),
+		Timestamp:           time.Now(),
+		ObservationTime:     step.Time,
+		Attempts:            attempts,
+	}, nil
+}
+
+// metNorwayResponse is the JSON shape of a compact Locationforecast response.
+type metNorwayResponse struct {
+	Properties struct {
+		Timeseries []metNorwayStep `json:"timeseries"`
+	} `json:"properties"`
+}
+
+// metNorwayStep is a single forecast step of a Locationforecast response.
+type metNorwayStep struct {
+	Time time.Time `json:"time"`
+	Data struct {
+		Instant struct {
+			Details struct {
+				AirPressureAtSeaLevel *float64 `json:"air_pressure_at_sea_level"`
+				AirTemperature        *float64 `json:"air_temperature"`
+				CloudAreaFraction     *float64 `json:"cloud_area_fraction"`
+				RelativeHumidity      *float64 `json:"relative_humidity"`
+				WindFromDirection     *float64 `json:"wind_from_direction"`
+				WindSpeed             *float64 `json:"wind_speed"`
+			} `json:"details"`
+		} `json:"instant"`
+		Next1Hours struct {
+			Summary struct {
+				SymbolCode string `json:"symbol_code"`
+			} `json:"summary"`
+			Details struct {
+				PrecipitationAmount *float64 `json:"precipitation_amount"`
+			} `json:"details"`
+		} `json:"next_1_hours"`
+	} `json:"data"`
+}
+
+// current returns the latest step at or before now, or the first step if
+// the whole series lies in the future.
+func (r *metNorwayResponse) current(now time.Time) (*metNorwayStep, error) {
+	steps := r.Properties.Timeseries
+	if len(steps) == 0 {
+		return nil, &decodeError{errors.New("met-norway: empty timeseries")}
+	}
+
+	best := &steps[0]
+	for i := range steps {
+		if steps[i].Time.After(now) {
+			break
+		}
+		best = &steps[i]
+	}
+	return best, nil
+}
+
+// metNorwaySymbols maps MET Norway symbol codes (without the _day, _night
+// or _polartwilight variant suffix) to the closest WMO weather code.
+// Thunder variants are handled separately.
+var metNorwaySymbols = map[string]int{
+	"clearsky":          0,
+	"fair":              1,
+	"partlycloudy":      2,
+	"cloudy":            3,
+	"fog":               45,
+	"lightrain":         61,
+	"rain":              63,
+	"heavyrain":         65,
+	"lightsleet":        66,
+	"sleet":             67,
+	"heavysleet":        67,
+	"lightsnow":         71,
+	"snow":              73,
+	"heavysnow":         75,
+	"lightrainshowers":  80,
+	"rainshowers":       81,
+	"heavyrainshowers":  82,
+	"lightsleetshowers": 85,
+	"sleetshowers":      85,
+	"heavysleetshowers": 86,
+	"lightsnowshowers":  85,
+	"snowshowers":       85,
+	"heavysnowshowers":  86,
+}
+
+// metNorwaySymbolToWMO converts a MET Norway symbol code such as
+// "lightrainshowers_day" to a WMO weather code. Unknown codes map to 0.
+func metNorwaySymbolToWMO(symbol string) int {
+	if i := strings.IndexByte(symbol, '_'); i >= 0 {
+		symbol = symbol[:i]
+	}
+	if strings.Contains(symbol, "thunder") {
+		return 95
+	}
+	return metNorwaySymbols[symbol]
+}
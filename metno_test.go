@@ -0,0 +1,122 @@
+package weathersync
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// metNorwayPayload is a trimmed Locationforecast 2.0 compact response
+const metNorwayPayload = `{
+  "type": "Feature",
+  "geometry": {"type": "Point", "coordinates": [10.7522, 59.9139, 12]},
+  "properties": {
+    "meta": {
+      "updated_at": "2024-06-01T11:42:10Z",
+      "units": {"air_temperature": "celsius", "wind_speed": "m/s"}
+    },
+    "timeseries": [
+      {
+        "time": "2020-06-01T12:00:00Z",
+        "data": {
+          "instant": {"details": {
+            "air_pressure_at_sea_level": 1014.2,
+            "air_temperature": 17.4,
+            "cloud_area_fraction": 62.5,
+            "relative_humidity": 55.3,
+            "wind_from_direction": 201.7,
+            "wind_speed": 5.0
+          }},
+          "next_1_hours": {
+            "summary": {"symbol_code": "lightrainshowers_day"},
+            "details": {"precipitation_amount": 0.4}
+          }
+        }
+      },
+      {
+        "time": "2999-06-01T13:00:00Z",
+        "data": {
+          "instant": {"details": {"air_temperature": 99.0}},
+          "next_1_hours": {"summary": {"symbol_code": "clearsky_day"}}
+        }
+      }
+    ]
+  }
+}`
+
+// TestMETNorwayCurrent tests mapping of a Locationforecast response
+func TestMETNorwayCurrent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/weatherapi/locationforecast/2.0/compact" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if ua := r.Header.Get("User-Agent"); ua != "weathersync-test/1.0 ops@example.com" {
+			t.Errorf("Unexpected User-Agent %q", ua)
+		}
+		if lat := r.URL.Query().Get("lat"); lat != "59.9139" {
+			t.Errorf("Expected lat truncated to 4 decimals, got %s", lat)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(metNorwayPayload))
+	}))
+	defer server.Close()
+
+	client := New(WithProvider(NewMETNorway(server.URL, "weathersync-test/1.0 ops@example.com")))
+	location := Location{Name: "Oslo", Latitude: 59.913868, Longitude: 10.752245}
+
+	data, err := client.FetchWeather(context.Background(), location)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if data.Temperature != 17.4 {
+		t.Errorf("Expected temperature 17.4, got %f", data.Temperature)
+	}
+
+	if data.WindSpeed != 18 {
+		t.Errorf("Expected wind speed 18 km/h, got %f", data.WindSpeed)
+	}
+
+	if data.WeatherCode != 80 {
+		t.Errorf("Expected weather code 80, got %d", data.WeatherCode)
+	}
+
+	if data.Precipitation != 0.4 || data.Humidity != 55.3 || data.Pressure != 1014.2 {
+		t.Errorf("Unexpected data %+v", data)
+	}
+
+	// Not provided by the compact product
+	if !math.IsNaN(data.ApparentTemperature) || !math.IsNaN(data.WindGusts) || !math.IsNaN(data.Visibility) {
+		t.Errorf("Expected NaN for unreported fields, got %+v", data)
+	}
+}
+
+// TestMETNorwayRequiresUserAgent tests that requests without a User-Agent are refused
+func TestMETNorwayRequiresUserAgent(t *testing.T) {
+	client := New(WithProvider(NewMETNorway("http://127.0.0.1:0", "")))
+
+	if _, err := client.FetchWeather(context.Background(), Location{}); err == nil {
+		t.Fatal("Expected error without User-Agent, got nil")
+	}
+}
+
+// TestMETNorwaySymbolToWMO tests symbol code conversion
+func TestMETNorwaySymbolToWMO(t *testing.T) {
+	tests := map[string]int{
+		"clearsky_night":                  0,
+		"cloudy":                          3,
+		"heavysnow":                       75,
+		"rainandthunder":                  95,
+		"lightssnowshowersandthunder_day": 95,
+		"unknown":                         0,
+	}
+
+	for symbol, want := range tests {
+		if got := metNorwaySymbolToWMO(symbol); got != want {
+			t.Errorf("metNorwaySymbolToWMO(%q) = %d, want %d", symbol, got, want)
+		}
+	}
+}
@@ -0,0 +1,404 @@
+package weathersync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NWS is the Provider for the US National Weather Service API
+// (https://www.weather.gov/documentation/services-web-api). It only covers
+// locations in the United States and its territories.
+//
+// Each location is first resolved to its forecast gridpoint and nearest
+// observation station; the resolution is cached per location. Current
+// conditions come from the station's latest observation, falling back to
+// the first period of the hourly forecast when the observation is
+// unavailable or has no temperature.
+//
+// Like MET Norway, the NWS asks for a User-Agent identifying the application
+// and a contact, e.g. "acme-dispatch/1.0 ops@acme.example".
+type NWS struct {
+	apiURL    string
+	userAgent string
+	client    *Client
+
+	mu     sync.Mutex
+	points map[string]*nwsPoint
+}
+
+// nwsPoint is the resolved gridpoint metadata for a location.
+type nwsPoint struct {
+	forecastHourly string
+	observations   string // latest observation URL of the nearest station, if any
+}
+
+// NewNWS creates a National Weather Service provider using apiURL as base
+// URL (default "https://api.weather.gov" if empty) and the given identifying
+// User-Agent.
+func NewNWS(apiURL, userAgent string) *NWS {
+	if apiURL == "" {
+		apiURL = "https://api.weather.gov"
+	}
+	return &NWS{
+		apiURL:    apiURL,
+		userAgent: userAgent,
+		points:    make(map[string]*nwsPoint),
+	}
+}
+
+// Name implements Provider.
+func (p *NWS) Name() string {
+	return "nws"
+}
+
+func (p *NWS) bind(c *Client) {
+	p.client = c
+}
+
+// Current implements Provider. All values are converted to the metric units
+// documented on WeatherData.
+func (p *NWS) Current(ctx context.Context, location Location) (*WeatherData, error) {
+	if p.client == nil {
+		return nil, unboundError(p.Name())
+	}
+	if p.userAgent == "" {
+		return nil, errors.New("nws: an identifying User-Agent is required")
+	}
+
+	start := time.Now()
+
+	point, attempts, err := p.point(ctx, location)
+	if err != nil {
+		return nil, fmt.Errorf("nws: resolve gridpoint: %w", err)
+	}
+
+	var data *WeatherData
+	if point.observations != "" {
+		var n int
+		data, n, err = p.observation(ctx, point.observations)
+		attempts += n
+		if err != nil && ctx.Err() != nil {
+			return nil, err
+		}
+	}
+
+	if data == nil {
+		var n int
+		data, n, err = p.hourlyForecast(ctx, point.forecastHourly)
+		attempts += n
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data.Location = location
+	data.FetchDuration = time.Since(start)
+	data.Timestamp = time.Now()
+	data.Attempts = attempts
+	return data, nil
+}
+
+// header returns the request headers required by the NWS API.
+func (p *NWS) header() http.Header {
+	header := http.Header{}
+	header.Set("User-Agent", p.userAgent)
+	header.Set("Accept", "application/geo+json")
+	return header
+}
+
+// point returns the cached gridpoint for location, resolving it with the
+// /points and station list endpoints on first use. It also returns the number
+// of HTTP attempts made.
+func (p *NWS) point(ctx context.Context, location Location) (*nwsPoint, int, error) {
+	// The API redirects coordinates with more than 4 decimals
+	coords := fmt.Sprintf("%.4f,%.4f", location.Latitude, location.Longitude)
+
+	p.mu.Lock()
+	point, ok := p.points[coords]
+	p.mu.Unlock()
+	if ok {
+		return point, 0, nil
+	}
+
+	var pointResp struct {
+		Properties struct {
+			ForecastHourly      string `json:"forecastHourly"`
+			ObservationStations string `json:"observationStations"`
+		} `json:"properties"`
+	}
+	attempts, err := p.client.fetchJSONWithHeader(ctx, p.apiURL+"/points/"+coords, p.header(), &pointResp)
+	if err != nil {
+		return nil, attempts, err
+	}
+	if pointResp.Properties.ForecastHourly == "" {
+		return nil, attempts, &decodeError{errors.New("nws: point has no hourly forecast")}
+	}
+
+	point = &nwsPoint{forecastHourly: pointResp.Properties.ForecastHourly}
+
+	// Stations are sorted by distance; the first one is the nearest
+	if stations := pointResp.Properties.ObservationStations; stations != "" {
+		var stationResp struct {
+			Features []struct {
+				ID string `json:"id"`
+			} `json:"features"`
+		}
+		n, err := p.client.fetchJSONWithHeader(ctx, stations, p.header(), &stationResp)
+		attempts += n
+		if err != nil {
+			return nil, attempts, err
+		}
+		if len(stationResp.Features) > 0 && stationResp.Features[0].ID != "" {
+			point.observations = stationResp.Features[0].ID + "/observations/latest"
+		}
+	}
+
+	p.mu.Lock()
+	p.points[coords] = point
+	p.mu.Unlock()
+
+	return point, attempts, nil
+}
+
+// nwsValue is a quantitative value with a WMO unit code, as used by the
+// observation endpoints. Value is nil when the station did not report it.
+type nwsValue struct {
+	Value    *float64 `json:"value"`
+	UnitCode string   `json:"unitCode"`
+}
+
+// metric returns the value converted to the metric unit used by WeatherData
+// (°C, km/h, hPa, m, mm, %, degrees), or NaN if no value was reported.
+func (v nwsValue) metric() float64 {
+	if v.Value == nil {
+		return math.NaN()
+	}
+
+	unit := v.UnitCode
+	if i := strings.LastIndexByte(unit, ':'); i >= 0 {
+		unit = unit[i+1:]
+	}
+
+	x := *v.Value
+	switch unit {
+	case "degF":
+		return (x - 32) * 5 / 9
+	case "K":
+		return x - 273.15
+	case "m_s-1":
+		return x * 3.6
+	case "kn":
+		return x * 1.852
+	case "Pa":
+		return x / 100
+	case "km":
+		return x * 1000
+	case "cm":
+		return x * 10
+	}
+	// degC, km_h-1, hPa, m, mm, percent, degree_(angle)
+	return x
+}
+
+// observation fetches the latest station observation. It returns nil data
+// (and no error) if the observation has no temperature, so the caller can
+// fall back to the forecast.
+func (p *NWS) observation(ctx context.Context, url string) (*WeatherData, int, error) {
+	var obsResp struct {
+		Properties struct {
+			Timestamp             time.Time `json:"timestamp"`
+			TextDescription       string    `json:"textDescription"`
+			Temperature           nwsValue  `json:"temperature"`
+			RelativeHumidity      nwsValue  `json:"relativeHumidity"`
+			WindSpeed             nwsValue  `json:"windSpeed"`
+			WindDirection         nwsValue  `json:"windDirection"`
+			WindGust              nwsValue  `json:"windGust"`
+			SeaLevelPressure      nwsValue  `json:"seaLevelPressure"`
+			Visibility            nwsValue  `json:"visibility"`
+			PrecipitationLastHour nwsValue  `json:"precipitationLastHour"`
+			HeatIndex             nwsValue  `json:"heatIndex"`
+			WindChill             nwsValue  `json:"windChill"`
+			CloudLayers           []struct {
+				Amount string `json:"amount"`
+			} `json:"cloudLayers"`
+		} `json:"properties"`
+	}
+
+	attempts, err := p.client.fetchJSONWithHeader(ctx, url, p.header(), &obsResp)
+	if err != nil {
+		return nil, attempts, err
+	}
+
+	o := obsResp.Properties
+	if o.Temperature.Value == nil {
+		return nil, attempts, nil
+	}
+
+	temp := o.Temperature.metric()
+	apparent := temp
+	if o.HeatIndex.Value != nil {
+		apparent = o.HeatIndex.metric()
+	} else if o.WindChill.Value != nil {
+		apparent = o.WindChill.metric()
+	}
+
+	// Total cover is that of the most covered layer
+	cloudCover := math.NaN()
+	for _, layer := range o.CloudLayers {
+		if cover, ok := cloudAmounts[layer.Amount]; ok && (math.IsNaN(cloudCover) || cover > cloudCover) {
+			cloudCover = cover
+		}
+	}
+
+	return &WeatherData{
+		ObservationTime:     o.Timestamp,
+		Temperature:         temp,
+		ApparentTemperature: apparent,
+		Humidity:            o.RelativeHumidity.metric(),
+		Precipitation:       o.PrecipitationLastHour.metric(),
+		WeatherCode:         nwsTextToWMO(o.TextDescription),
+		WindSpeed:           o.WindSpeed.metric(),
+		WindDirection:       o.WindDirection.metric(),
+		WindGusts:           o.WindGust.metric(),
+		CloudCover:          cloudCover,
+		Visibility:          o.Visibility.metric(),
+		Pressure:            o.SeaLevelPressure.metric(),
+	}, attempts, nil
+}
+
+// hourlyForecast fetches the hourly forecast and converts its first period.
+// The forecast has no precipitation amount, gusts, cloud cover, visibility or
+// pressure; these fields are NaN.
+func (p *NWS) hourlyForecast(ctx context.Context, url string) (*WeatherData, int, error) {
+	var forecastResp struct {
+		Properties struct {
+			Periods []struct {
+				StartTime        time.Time `json:"startTime"`
+				Temperature      *float64  `json:"temperature"`
+				TemperatureUnit  string    `json:"temperatureUnit"`
+				RelativeHumidity nwsValue  `json:"relativeHumidity"`
+				WindSpeed        string    `json:"windSpeed"`
+				WindDirection    string    `json:"windDirection"`
+				ShortForecast    string    `json:"shortForecast"`
+			} `json:"periods"`
+		} `json:"properties"`
+	}
+
+	attempts, err := p.client.fetchJSONWithHeader(ctx, url, p.header(), &forecastResp)
+	if err != nil {
+		return nil, attempts, err
+	}
+
+	periods := forecastResp.Properties.Periods
+	if len(periods) == 0 {
+		return nil, attempts, &decodeError{errors.New("nws: forecast has no periods")}
+	}
+	period := periods[0]
+
+	temp := valueOrNaN(period.Temperature)
+	if period.TemperatureUnit == "F" {
+		temp = (temp - 32) * 5 / 9
+	}
+
+	windSpeed, err := parseNWSWindSpeed(period.WindSpeed)
+	if err != nil {
+		return nil, attempts, &decodeError{err}
+	}
+
+	direction, ok := compassDegrees[period.WindDirection]
+	if !ok {
+		direction = math.NaN()
+	}
+
+	nan := math.NaN()
+	return &WeatherData{
+		ObservationTime:     period.StartTime,
+		Temperature:         temp,
+		ApparentTemperature: temp,
+		Humidity:            period.RelativeHumidity.metric(),
+		Precipitation:       nan,
+		WeatherCode:         nwsTextToWMO(period.ShortForecast),
+		WindSpeed:           windSpeed,
+		WindDirection:       direction,
+		WindGusts:           nan,
+		CloudCover:          nan,
+		Visibility:          nan,
+		Pressure:            nan,
+	}, attempts, nil
+}
+
+// parseNWSWindSpeed converts forecast wind strings such as "10 mph",
+// "16 km/h" or "5 to 10 mph" to km/h. Ranges use their upper bound.
+func parseNWSWindSpeed(s string) (float64, error) {
+	fields := strings.Fields(s)
+	if len(fields) < 2 {
+		return 0, fmt.Errorf("nws: invalid wind speed %q", s)
+	}
+
+	value, err := strconv.ParseFloat(fields[len(fields)-2], 64)
+	if err != nil {
+		return 0, fmt.Errorf("nws: invalid wind speed %q: %w", s, err)
+	}
+
+	switch fields[len(fields)-1] {
+	case "mph":
+		return value * 1.609344, nil
+	case "km/h":
+		return value, nil
+	case "kt", "kn":
+		return value * 1.852, nil
+	case "m/s":
+		return value * 3.6, nil
+	}
+	return 0, fmt.Errorf("nws: unknown wind speed unit in %q", s)
+}
+
+// cloudAmounts maps the METAR cloud amounts of observation cloud layers to
+// a cloud cover percentage, using the middle of each okta range.
+var cloudAmounts = map[string]float64{
+	"SKC": 0, "CLR": 0, "FEW": 19, "SCT": 44, "BKN": 75, "OVC": 100, "VV": 100,
+}
+
+// compassDegrees maps 16-point compass directions to degrees.
+var compassDegrees = map[string]float64{
+	"N": 0, "NNE": 22.5, "NE": 45, "ENE": 67.5,
+	"E": 90, "ESE": 112.5, "SE": 135, "SSE": 157.5,
+	"S": 180, "SSW": 202.5, "SW": 225, "WSW": 247.5,
+	"W": 270, "WNW": 292.5, "NW": 315, "NNW": 337.5,
+}
+
+// nwsTextToWMO derives an approximate WMO weather code from an NWS textual
+// description such as "Chance Rain Showers" or "Mostly Cloudy".
+func nwsTextToWMO(text string) int {
+	t := strings.ToLower(text)
+	switch {
+	case strings.Contains(t, "thunder"):
+		return 95
+	case strings.Contains(t, "snow"):
+		return 73
+	case strings.Contains(t, "sleet"), strings.Contains(t, "freezing"):
+		return 67
+	case strings.Contains(t, "showers"):
+		return 80
+	case strings.Contains(t, "rain"):
+		return 63
+	case strings.Contains(t, "drizzle"):
+		return 53
+	case strings.Contains(t, "fog"):
+		return 45
+	case strings.Contains(t, "overcast"), t == "cloudy", strings.Contains(t, "mostly cloudy"):
+		return 3
+	case strings.Contains(t, "partly"):
+		return 2
+	case strings.Contains(t, "mostly"):
+		return 1
+	}
+	return 0
+}
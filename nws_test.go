@@ -0,0 +1,191 @@
+package weathersync
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newNWSServer returns a stand-in for api.weather.gov. observation is the
+// body of the latest observation response.
+func newNWSServer(t *testing.T, observation string, pointCalls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") == "" {
+			t.Error("Missing User-Agent header")
+		}
+
+		base := "http://" + r.Host
+		w.Header().Set("Content-Type", "application/geo+json")
+
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/points/"):
+			atomic.AddInt32(pointCalls, 1)
+			w.Write([]byte(`{"properties": {
+				"forecastHourly": "` + base + `/gridpoints/OKX/33,35/forecast/hourly",
+				"observationStations": "` + base + `/gridpoints/OKX/33,35/stations"
+			}}`))
+		case r.URL.Path == "/gridpoints/OKX/33,35/stations":
+			w.Write([]byte(`{"features": [{"id": "` + base + `/stations/KNYC"}, {"id": "` + base + `/stations/KLGA"}]}`))
+		case r.URL.Path == "/stations/KNYC/observations/latest":
+			w.Write([]byte(observation))
+		case r.URL.Path == "/gridpoints/OKX/33,35/forecast/hourly":
+			w.Write([]byte(`{"properties": {"periods": [{
+				"temperature": 68,
+				"temperatureUnit": "F",
+				"relativeHumidity": {"unitCode": "wmoUnit:percent", "value": 60},
+				"windSpeed": "5 to 10 mph",
+				"windDirection": "SW",
+				"shortForecast": "Chance Rain Showers"
+			}]}}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+// TestNWSCurrentObservation tests unit conversion of a station observation
+// and caching of the gridpoint resolution
+func TestNWSCurrentObservation(t *testing.T) {
+	var pointCalls int32
+	server := newNWSServer(t, `{"properties": {
+		"textDescription": "Mostly Cloudy",
+		"temperature": {"unitCode": "wmoUnit:degF", "value": 50},
+		"relativeHumidity": {"unitCode": "wmoUnit:percent", "value": 71.5},
+		"windSpeed": {"unitCode": "wmoUnit:km_h-1", "value": 14.8},
+		"windDirection": {"unitCode": "wmoUnit:degree_(angle)", "value": 230},
+		"windGust": {"unitCode": "wmoUnit:km_h-1", "value": null},
+		"seaLevelPressure": {"unitCode": "wmoUnit:Pa", "value": 101320},
+		"visibility": {"unitCode": "wmoUnit:m", "value": 16090},
+		"cloudLayers": [{"amount": "FEW"}, {"amount": "BKN"}]
+	}}`, &pointCalls)
+	defer server.Close()
+
+	client := New(WithProvider(NewNWS(server.URL, "weathersync-test/1.0 ops@example.com")))
+	location := Location{Name: "New York", Latitude: 40.71, Longitude: -74.01}
+
+	data, err := client.FetchWeather(context.Background(), location)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if data.Temperature != 10 {
+		t.Errorf("Expected 10°C, got %f", data.Temperature)
+	}
+
+	if data.WindSpeed != 14.8 || data.WindDirection != 230 || !math.IsNaN(data.WindGusts) {
+		t.Errorf("Unexpected wind %f/%f/%f", data.WindSpeed, data.WindDirection, data.WindGusts)
+	}
+
+	if data.Pressure != 1013.2 || data.Visibility != 16090 || data.Humidity != 71.5 {
+		t.Errorf("Unexpected data %+v", data)
+	}
+
+	if data.WeatherCode != 3 {
+		t.Errorf("Expected weather code 3, got %d", data.WeatherCode)
+	}
+
+	if data.CloudCover != 75 {
+		t.Errorf("Expected cloud cover 75 from the broken layer, got %f", data.CloudCover)
+	}
+
+	// A second fetch reuses the resolved gridpoint
+	if _, err := client.FetchWeather(context.Background(), location); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := atomic.LoadInt32(&pointCalls); got != 1 {
+		t.Errorf("Expected 1 points lookup, got %d", got)
+	}
+}
+
+// TestNWSObservationUnreported tests that values missing from an observation
+// are NaN rather than zero
+func TestNWSObservationUnreported(t *testing.T) {
+	var pointCalls int32
+	server := newNWSServer(t, `{"properties": {
+		"temperature": {"unitCode": "wmoUnit:degC", "value": 12.0}
+	}}`, &pointCalls)
+	defer server.Close()
+
+	client := New(WithProvider(NewNWS(server.URL, "weathersync-test/1.0 ops@example.com")))
+
+	data, err := client.FetchWeather(context.Background(), Location{Name: "New York", Latitude: 40.71, Longitude: -74.01})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if data.Temperature != 12 {
+		t.Errorf("Expected 12°C, got %f", data.Temperature)
+	}
+
+	for name, v := range map[string]float64{
+		"Humidity": data.Humidity, "Precipitation": data.Precipitation,
+		"WindSpeed": data.WindSpeed, "WindDirection": data.WindDirection,
+		"WindGusts": data.WindGusts, "CloudCover": data.CloudCover,
+		"Visibility": data.Visibility, "Pressure": data.Pressure,
+	} {
+		if !math.IsNaN(v) {
+			t.Errorf("Expected NaN %s, got %f", name, v)
+		}
+	}
+}
+
+// TestNWSCurrentForecastFallback tests that the hourly forecast is used when
+// the observation has no temperature
+func TestNWSCurrentForecastFallback(t *testing.T) {
+	var pointCalls int32
+	server := newNWSServer(t, `{"properties": {"temperature": {"unitCode": "wmoUnit:degC", "value": null}}}`, &pointCalls)
+	defer server.Close()
+
+	client := New(WithProvider(NewNWS(server.URL, "weathersync-test/1.0 ops@example.com")))
+
+	data, err := client.FetchWeather(context.Background(), Location{Name: "New York", Latitude: 40.71, Longitude: -74.01})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if math.Abs(data.Temperature-20) > 1e-9 {
+		t.Errorf("Expected 20°C, got %f", data.Temperature)
+	}
+
+	if math.Abs(data.WindSpeed-16.09344) > 1e-9 {
+		t.Errorf("Expected 16.09 km/h, got %f", data.WindSpeed)
+	}
+
+	if data.WindDirection != 225 || data.Humidity != 60 || data.WeatherCode != 80 {
+		t.Errorf("Unexpected data %+v", data)
+	}
+
+	// The forecast does not report these
+	if !math.IsNaN(data.Precipitation) || !math.IsNaN(data.Visibility) || !math.IsNaN(data.Pressure) {
+		t.Errorf("Expected NaN for unreported fields, got %+v", data)
+	}
+}
+
+// TestParseNWSWindSpeed tests forecast wind speed strings
+func TestParseNWSWindSpeed(t *testing.T) {
+	tests := map[string]float64{
+		"16 km/h":     16,
+		"10 kt":       18.52,
+		"5 to 10 mph": 16.09344,
+	}
+
+	for input, want := range tests {
+		got, err := parseNWSWindSpeed(input)
+		if err != nil {
+			t.Errorf("parseNWSWindSpeed(%q): unexpected error %v", input, err)
+			continue
+		}
+		if math.Abs(got-want) > 1e-9 {
+			t.Errorf("parseNWSWindSpeed(%q) = %f, want %f", input, got, want)
+		}
+	}
+
+	if _, err := parseNWSWindSpeed("calm"); err == nil {
+		t.Error("Expected error for invalid wind speed")
+	}
+}
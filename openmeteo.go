@@ -0,0 +1,247 @@
+package weathersync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+)
+
+// weatherVariables is the list of Open-Meteo variables requested for hourly
+// forecasts and history. It mirrors the fields of WeatherData and matches
+// DefaultVariables.
+const weatherVariables = "temperature_2m,apparent_temperature,relative_humidity_2m,precipitation,weather_code,wind_speed_10m,wind_direction_10m,wind_gusts_10m,cloud_cover,visibility,pressure_msl"
+
+// OpenMeteo is the Provider for the Open-Meteo forecast API
+// (https://open-meteo.com). It is the default provider of a Client.
+type OpenMeteo struct {
+	apiURL string
+	client *Client
+}
+
+// NewOpenMeteo creates an Open-Meteo provider using apiURL as base URL.
+// If apiURL is empty, the client's URL (see WithAPIURL) is used.
+func NewOpenMeteo(apiURL string) *OpenMeteo {
+	return &OpenMeteo{apiURL: apiURL}
+}
+
+// Name implements Provider.
+func (p *OpenMeteo) Name() string {
+	return "open-meteo"
+}
+
+func (p *OpenMeteo) bind(c *Client) {
+	p.client = c
+}
+
+// baseURL returns the API base URL in effect for this provider.
+func (p *OpenMeteo) baseURL() string {
+	if p.apiURL != "" {
+		return p.apiURL
+	}
+	return p.client.apiURL
+}
+
+// WithModels selects the Open-Meteo weather models to use, such as
+// "icon_seamless", "gfs_seamless" or "ecmwf_ifs04". With more than one
+// model, WeatherData.Models holds one result per model and the top-level
+// fields are those of the first model. Default is Open-Meteo's best-match
+// blend. Use Models to override it for a single call.
+func WithModels(models ...string) Option {
+	return func(c *Client) {
+		c.models = models
+	}
+}
+
+// WithTimezone sets the timezone in which Open-Meteo reports the observation
+// time of current conditions and delimits the days of daily forecasts and
+// history: an IANA name such as "America/Denver", "GMT", or "auto" for the
+// timezone of each location. Default is "auto". Hourly forecasts are not
+// affected and stay in UTC.
+func WithTimezone(name string) Option {
+	return func(c *Client) {
+		c.timezone = name
+	}
+}
+
+// Current implements Provider using the current= block of /v1/forecast.
+func (p *OpenMeteo) Current(ctx context.Context, location Location) (*WeatherData, error) {
+	if p.client == nil {
+		return nil, unboundError(p.Name())
+	}
+
+	q, ok := queryFrom(ctx)
+	if !ok {
+		q = p.client.newQuery(nil)
+	}
+
+	url := fmt.Sprintf("%s/v1/forecast?latitude=%f&longitude=%f&%s%s",
+		p.baseURL(), location.Latitude, location.Longitude, q.params(), elevationParam(location))
+
+	start := time.Now()
+
+	var apiResp currentResponse
+	attempts, err := p.client.fetchJSON(ctx, url, &apiResp)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := apiResp.weatherData(location, start, q)
+	if err != nil {
+		return nil, err
+	}
+	data.Attempts = attempts
+	return data, nil
+}
+
+// currentResponse is the JSON shape of an Open-Meteo response for the
+// current= block of the forecast endpoint. Variables are kept by name since
+// requesting several models suffixes them, e.g. "temperature_2m_icon_seamless".
+type currentResponse struct {
+	responseTimezone
+	Current map[string]json.RawMessage `json:"current"`
+}
+
+// responseTimezone holds the timezone fields of an Open-Meteo response.
+// Local times in the response are in this timezone.
+type responseTimezone struct {
+	Timezone             string `json:"timezone"`
+	TimezoneAbbreviation string `json:"timezone_abbreviation"`
+	UTCOffsetSeconds     int    `json:"utc_offset_seconds"`
+}
+
+// value returns the numeric variable name. ok is false if it is absent,
+// null or not a number.
+func (r *currentResponse) value(name string) (value float64, ok bool) {
+	var v *float64
+	if raw, found := r.Current[name]; found {
+		// Non-numeric values are treated like missing ones
+		_ = json.Unmarshal(raw, &v)
+	}
+	if v == nil {
+		return 0, false
+	}
+	return *v, true
+}
+
+// float returns the numeric variable name, or NaN if it is absent, null or
+// not a number.
+func (r *currentResponse) float(name string) float64 {
+	if v, ok := r.value(name); ok {
+		return v
+	}
+	return math.NaN()
+}
+
+// weatherData converts the response into WeatherData for location, using the
+// variables and models of q. start is the time the request was issued and is
+// used for FetchDuration. With more than one model, per-model results are
+// stored in Models.
+func (r *currentResponse) weatherData(location Location, start time.Time, q fetchQuery) (*WeatherData, error) {
+	observed, err := r.observationTime()
+	if err != nil {
+		return nil, err
+	}
+
+	var interval time.Duration
+	if seconds, ok := r.value("interval"); ok {
+		interval = time.Duration(seconds) * time.Second
+	}
+
+	r.fillTimezone(&location, q.timezone)
+
+	results := make([]*WeatherData, 0, len(q.models))
+	if len(q.models) <= 1 {
+		results = append(results, r.modelData(location, start, q, ""))
+	} else {
+		for _, model := range q.models {
+			results = append(results, r.modelData(location, start, q, "_"+model))
+		}
+	}
+	for _, data := range results {
+		data.ObservationTime = observed
+		data.Interval = interval
+	}
+
+	data := results[0]
+	if len(q.models) > 1 {
+		// Copy all results before attaching them, so the first model's
+		// entry does not refer back to the map itself
+		models := make(map[string]WeatherData, len(q.models))
+		for i, model := range q.models {
+			models[model] = *results[i]
+		}
+		data.Models = models
+	}
+	return data, nil
+}
+
+// observationTime parses current.time, a local time in the response's
+// timezone. It returns the zero time if the response has none.
+func (r *currentResponse) observationTime() (time.Time, error) {
+	var ts string
+	if raw, ok := r.Current["time"]; ok {
+		if err := json.Unmarshal(raw, &ts); err != nil {
+			return time.Time{}, &decodeError{fmt.Errorf("time: %w", err)}
+		}
+	}
+	if ts == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.ParseInLocation(apiTimeLayout, ts, r.timeLocation())
+	if err != nil {
+		return time.Time{}, &decodeError{fmt.Errorf("parse time %q: %w", ts, err)}
+	}
+	return t, nil
+}
+
+// fillTimezone sets location.Timezone to the response timezone if the
+// request asked for the location's own one ("auto"). Any other timezone was
+// chosen by the caller and says nothing about the location.
+func (r *responseTimezone) fillTimezone(location *Location, requested string) {
+	if requested == "auto" && r.Timezone != "" {
+		location.Timezone = r.Timezone
+	}
+}
+
+// timeLocation returns the timezone the response's local times are in.
+func (r *responseTimezone) timeLocation() *time.Location {
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil || r.Timezone == "" {
+		// No tz database available, or no timezone in the response
+		return time.FixedZone(r.TimezoneAbbreviation, r.UTCOffsetSeconds)
+	}
+	return loc
+}
+
+// modelData converts the variables of q, with suffix appended to their
+// names, into WeatherData. Fields whose variable is missing from the
+// response or was not requested are NaN.
+func (r *currentResponse) modelData(location Location, start time.Time, q fetchQuery, suffix string) *WeatherData {
+	nan := math.NaN()
+	data := &WeatherData{
+		Location:            location,
+		Temperature:         nan,
+		ApparentTemperature: nan,
+		Humidity:            nan,
+		Precipitation:       nan,
+		WindSpeed:           nan,
+		WindDirection:       nan,
+		WindGusts:           nan,
+		CloudCover:          nan,
+		Visibility:          nan,
+		Pressure:            nan,
+		Units:               q.units.normalized(),
+		FetchDuration:       time.Since(start),
+		Timestamp:           time.Now(),
+	}
+
+	for _, v := range q.variables {
+		if value, ok := r.value(string(v) + suffix); ok {
+			data.setVariable(v, value)
+		}
+	}
+	return data
+}
@@ -0,0 +1,180 @@
+package weathersync
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestFetchWeatherModels tests that several models are requested and that
+// their suffixed variables are split into per-model results
+func TestFetchWeatherModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if models := r.URL.Query().Get("models"); models != "icon_seamless,gfs_seamless" {
+			t.Errorf("Expected models=icon_seamless,gfs_seamless, got %q", models)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"current": {
+				"temperature_2m_icon_seamless": 14.1,
+				"weather_code_icon_seamless": 3,
+				"temperature_2m_gfs_seamless": 15.6,
+				"weather_code_gfs_seamless": 61
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL), WithModels("icon_seamless", "gfs_seamless"))
+
+	data, err := client.FetchWeather(context.Background(), Location{Name: "Berlin"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if data.Temperature != 14.1 || data.WeatherCode != 3 {
+		t.Errorf("Expected top-level data of first model, got %f/%d", data.Temperature, data.WeatherCode)
+	}
+
+	if len(data.Models) != 2 {
+		t.Fatalf("Expected 2 model results, got %d", len(data.Models))
+	}
+
+	gfs := data.Models["gfs_seamless"]
+	if gfs.Temperature != 15.6 || gfs.WeatherCode != 61 {
+		t.Errorf("Unexpected gfs_seamless result %+v", gfs)
+	}
+	if gfs.Location.Name != "Berlin" {
+		t.Errorf("Expected model result for Berlin, got %q", gfs.Location.Name)
+	}
+
+	for model, result := range data.Models {
+		if result.Models != nil {
+			t.Errorf("Expected no nested model results in %s", model)
+		}
+	}
+}
+
+// TestFetchWeatherModelsOverride tests that a per-call model selection
+// replaces the client default and is cached separately
+func TestFetchWeatherModelsOverride(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("models") == "ecmwf_ifs04" {
+			w.Write([]byte(`{"current": {"temperature_2m": 9.5}}`))
+			return
+		}
+		w.Write([]byte(`{"current": {"temperature_2m": 11.0}}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL), WithModels("icon_seamless"), WithCache(NewMemoryCache(10, 0)))
+	location := Location{Name: "Berlin", Latitude: 52.52, Longitude: 13.41}
+
+	data, err := client.FetchWeather(context.Background(), location)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if data.Temperature != 11.0 {
+		t.Errorf("Expected 11.0 from default model, got %f", data.Temperature)
+	}
+
+	data, err = client.FetchWeather(context.Background(), location, Models("ecmwf_ifs04"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if data.Temperature != 9.5 || data.Cached {
+		t.Errorf("Expected uncached 9.5 from override model, got %f (cached %v)", data.Temperature, data.Cached)
+	}
+
+	// A single model does not produce per-model results
+	if data.Models != nil {
+		t.Errorf("Expected no per-model results, got %v", data.Models)
+	}
+
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("Expected 2 requests, got %d", got)
+	}
+}
+
+// TestFetchWeatherObservationTime tests parsing of the observation time,
+// interval and timezone of a response
+func TestFetchWeatherObservationTime(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tz := r.URL.Query().Get("timezone"); tz != "auto" {
+			t.Errorf("Expected timezone=auto, got %q", tz)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"timezone": "Europe/Berlin",
+			"timezone_abbreviation": "CEST",
+			"utc_offset_seconds": 7200,
+			"current": {
+				"time": "2024-06-01T14:15",
+				"interval": 900,
+				"temperature_2m": 21.3
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL))
+
+	data, err := client.FetchWeather(context.Background(), Location{Name: "Berlin"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := time.Date(2024, 6, 1, 12, 15, 0, 0, time.UTC)
+	if !data.ObservationTime.Equal(want) {
+		t.Errorf("Expected observation time %v, got %v", want, data.ObservationTime)
+	}
+	if _, offset := data.ObservationTime.Zone(); offset != 7200 {
+		t.Errorf("Expected observation time in UTC+2, got offset %d", offset)
+	}
+
+	if data.Interval != 15*time.Minute {
+		t.Errorf("Expected interval 15m, got %v", data.Interval)
+	}
+
+	if data.Location.Timezone != "Europe/Berlin" {
+		t.Errorf("Expected timezone Europe/Berlin, got %q", data.Location.Timezone)
+	}
+}
+
+// TestWithTimezone tests that a fixed timezone is passed to the API
+func TestWithTimezone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tz := r.URL.Query().Get("timezone"); tz != "America/Denver" {
+			t.Errorf("Expected timezone=America/Denver, got %q", tz)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"timezone": "America/Denver", "utc_offset_seconds": -21600, "current": {"time": "2024-06-01T06:15"}}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL), WithTimezone("America/Denver"))
+
+	data, err := client.FetchWeather(context.Background(), Location{Name: "Berlin"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if want := time.Date(2024, 6, 1, 12, 15, 0, 0, time.UTC); !data.ObservationTime.Equal(want) {
+		t.Errorf("Expected observation time %v, got %v", want, data.ObservationTime)
+	}
+
+	// The requested timezone is not the location's own
+	if data.Location.Timezone != "" {
+		t.Errorf("Expected no location timezone, got %q", data.Location.Timezone)
+	}
+}
@@ -0,0 +1,73 @@
+package weathersync
+
+import (
+	"context"
+	"sync"
+)
+
+// WithMaxConcurrency limits FetchMultiple to n requests in flight at a time,
+// using a fixed pool of n worker goroutines. Values below 1 mean no limit
+// (one goroutine per request, the default).
+func WithMaxConcurrency(n int) Option {
+	return func(c *Client) {
+		c.maxConcurrency = n
+	}
+}
+
+// forEach calls fn(i) for every i in [0, n) and waits for all calls to finish.
+//
+// Without a concurrency limit every call runs in its own goroutine. Otherwise
+// a pool of c.maxConcurrency workers processes the jobs in index order, and
+// once ctx is cancelled no further jobs are started: skip(i) is called for
+// each job that never ran instead.
+func (c *Client) forEach(ctx context.Context, n int, fn func(i int), skip func(i int)) {
+	var wg sync.WaitGroup
+
+	if c.maxConcurrency < 1 {
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func(index int) {
+				defer wg.Done()
+				fn(index)
+			}(i)
+		}
+		wg.Wait()
+		return
+	}
+
+	workers := c.maxConcurrency
+	if workers > n {
+		workers = n
+	}
+
+	jobs := make(chan int)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if ctx.Err() != nil {
+					skip(i)
+					continue
+				}
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		select {
+		case jobs <- i:
+			continue
+		case <-ctx.Done():
+		}
+
+		// Cancelled: report every job that was not handed to a worker
+		for ; i < n; i++ {
+			skip(i)
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+}
@@ -0,0 +1,94 @@
+package weathersync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestFetchMultipleMaxConcurrency tests that no more than the configured
+// number of requests are in flight and that result order is preserved
+func TestFetchMultipleMaxConcurrency(t *testing.T) {
+	var inFlight, peak int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"current": {"temperature_2m": ` + r.URL.Query().Get("latitude") + `}}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL), WithMaxConcurrency(3))
+
+	locations := make([]Location, 12)
+	for i := range locations {
+		locations[i] = Location{Name: fmt.Sprintf("City%d", i), Latitude: float64(i)}
+	}
+
+	results := client.FetchMultiple(context.Background(), locations)
+
+	if got := atomic.LoadInt32(&peak); got > 3 {
+		t.Errorf("Expected at most 3 concurrent requests, got %d", got)
+	}
+
+	for i, result := range results {
+		if result.Error != nil {
+			t.Fatalf("Result %d: unexpected error %v", i, result.Error)
+		}
+		if result.Location.Name != locations[i].Name || result.Temperature != float64(i) {
+			t.Errorf("Result %d out of order: %s %.0f", i, result.Location.Name, result.Temperature)
+		}
+	}
+}
+
+// TestFetchMultipleMaxConcurrencyCancel tests that cancellation stops
+// scheduling and reports ctx.Err() for un-fetched locations
+func TestFetchMultipleMaxConcurrencyCancel(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(50 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"current": {"temperature_2m": 1}}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL), WithMaxConcurrency(1))
+
+	locations := make([]Location, 10)
+	for i := range locations {
+		locations[i] = Location{Name: fmt.Sprintf("City%d", i)}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 75*time.Millisecond)
+	defer cancel()
+
+	results := client.FetchMultiple(ctx, locations)
+
+	if got := atomic.LoadInt32(&calls); got >= int32(len(locations)) {
+		t.Errorf("Expected scheduling to stop after cancellation, got %d calls", got)
+	}
+
+	last := results[len(results)-1]
+	if !errors.Is(last.Error, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded for un-fetched location, got %v", last.Error)
+	}
+	if last.Location.Name != locations[len(locations)-1].Name {
+		t.Errorf("Expected location %s, got %s", locations[len(locations)-1].Name, last.Location.Name)
+	}
+}
@@ -0,0 +1,49 @@
+package weathersync
+
+import (
+	"context"
+	"math"
+)
+
+// Provider is a source of current weather data. The Client handles caching,
+// request coalescing and concurrency on top of it, so implementations only
+// need to fetch and convert data for a single location.
+type Provider interface {
+	// Name returns a short identifier for the backend, such as "open-meteo".
+	Name() string
+
+	// Current returns the current weather at location.
+	Current(ctx context.Context, location Location) (*WeatherData, error)
+}
+
+// WithProvider sets the backend used by FetchWeather and FetchMultiple.
+// Default is the Open-Meteo provider.
+//
+// Built-in providers share the client's HTTP client, timeouts, retry policy
+// and rate limit, and should therefore not be shared between clients.
+func WithProvider(p Provider) Option {
+	return func(c *Client) {
+		c.provider = p
+	}
+}
+
+// binder is implemented by built-in providers that issue requests through
+// the Client they are attached to.
+type binder interface {
+	bind(c *Client)
+}
+
+// valueOrNaN returns *v, or NaN if the value was not reported.
+func valueOrNaN(v *float64) float64 {
+	if v == nil {
+		return math.NaN()
+	}
+	return *v
+}
+
+// unboundError is returned by built-in providers used without a Client.
+type unboundError string
+
+func (e unboundError) Error() string {
+	return string(e) + " provider is not attached to a Client (see WithProvider)"
+}
@@ -0,0 +1,79 @@
+package weathersync
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// stubProvider is a Provider returning canned data for tests
+type stubProvider struct {
+	name string
+	temp float64
+	err  error
+}
+
+func (p *stubProvider) Name() string {
+	return p.name
+}
+
+func (p *stubProvider) Current(ctx context.Context, location Location) (*WeatherData, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &WeatherData{Location: location, Temperature: p.temp}, nil
+}
+
+// TestDefaultProvider tests that clients use Open-Meteo by default
+func TestDefaultProvider(t *testing.T) {
+	client := New()
+
+	if _, ok := client.provider.(*OpenMeteo); !ok {
+		t.Errorf("Expected *OpenMeteo default provider, got %T", client.provider)
+	}
+}
+
+// TestFetchMultipleWithProvider tests that a custom provider is used by
+// FetchMultiple with the usual result shape
+func TestFetchMultipleWithProvider(t *testing.T) {
+	client := New(WithProvider(&stubProvider{name: "stub", temp: 21.5}))
+
+	locations := []Location{
+		{Name: "A", Latitude: 1, Longitude: 1},
+		{Name: "B", Latitude: 2, Longitude: 2},
+	}
+
+	results := client.FetchMultiple(context.Background(), locations)
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+
+	for i, result := range results {
+		if result.Error != nil {
+			t.Errorf("Result %d: unexpected error %v", i, result.Error)
+		}
+		if result.Location.Name != locations[i].Name || result.Temperature != 21.5 {
+			t.Errorf("Result %d: unexpected data %+v", i, result)
+		}
+	}
+}
+
+// TestFetchWeatherProviderError tests that provider errors are returned
+func TestFetchWeatherProviderError(t *testing.T) {
+	wantErr := errors.New("station offline")
+	client := New(WithProvider(&stubProvider{name: "stub", err: wantErr}))
+
+	_, err := client.FetchWeather(context.Background(), Location{Name: "A"})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("Expected %v, got %v", wantErr, err)
+	}
+}
+
+// TestOpenMeteoUnbound tests that a provider used without a Client fails cleanly
+func TestOpenMeteoUnbound(t *testing.T) {
+	_, err := NewOpenMeteo("").Current(context.Background(), Location{})
+	if err == nil {
+		t.Fatal("Expected error for unbound provider, got nil")
+	}
+}
@@ -0,0 +1,88 @@
+package weathersync
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// FetchOption customizes a single FetchWeather or FetchMultiple call,
+// overriding the corresponding client-wide setting.
+type FetchOption func(*fetchQuery)
+
+// fetchQuery holds the settings of a current weather request.
+type fetchQuery struct {
+	models    []string
+	variables []Variable
+	units     Units
+	timezone  string
+}
+
+// Models requests data from the given Open-Meteo weather models for this
+// call only, overriding WithModels.
+func Models(models ...string) FetchOption {
+	return func(q *fetchQuery) {
+		q.models = models
+	}
+}
+
+// newQuery returns the client-wide settings with opts applied.
+func (c *Client) newQuery(opts []FetchOption) fetchQuery {
+	q := fetchQuery{
+		models:    c.models,
+		variables: c.variables,
+		units:     c.units,
+		timezone:  c.timezone,
+	}
+
+	for _, opt := range opts {
+		opt(&q)
+	}
+
+	if len(q.variables) == 0 {
+		q.variables = DefaultVariables()
+	}
+
+	return q
+}
+
+// requests reports whether v is among the requested variables.
+func (q fetchQuery) requests(v Variable) bool {
+	for _, requested := range q.variables {
+		if requested == v {
+			return true
+		}
+	}
+	return false
+}
+
+// params returns the Open-Meteo query string for the current= block,
+// including models, units and timezone but without the coordinates.
+// It also identifies the request in cache keys.
+func (q fetchQuery) params() string {
+	params := "current=" + joinVariables(q.variables)
+	if len(q.models) > 0 {
+		params += "&models=" + strings.Join(q.models, ",")
+	}
+	return params + q.units.params() + timezoneParam(q.timezone)
+}
+
+// timezoneParam returns the query string fragment selecting timezone.
+func timezoneParam(timezone string) string {
+	return "&timezone=" + url.QueryEscape(timezone)
+}
+
+// queryKey is the context key under which FetchWeather passes its
+// settings to the provider.
+type queryKey struct{}
+
+// withQuery returns a copy of ctx carrying q.
+func withQuery(ctx context.Context, q fetchQuery) context.Context {
+	return context.WithValue(ctx, queryKey{}, q)
+}
+
+// queryFrom returns the settings carried by ctx, if any.
+func queryFrom(ctx context.Context) (fetchQuery, bool) {
+	q, ok := ctx.Value(queryKey{}).(fetchQuery)
+	return q, ok
+}
@@ -0,0 +1,81 @@
+package weathersync
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// WithRateLimit throttles all requests made by the Client to
+// requestsPerSecond on average, allowing bursts of up to burst requests.
+// Every fetch method, including retries, draws from the same token bucket,
+// so goroutines sharing one Client are throttled collectively. When the
+// budget is exhausted, requests wait for a token or until ctx is done.
+// Default is no rate limit.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *Client) {
+		if requestsPerSecond <= 0 {
+			c.limiter = nil
+			return
+		}
+		if burst < 1 {
+			burst = 1
+		}
+		c.limiter = &rateLimiter{
+			rate:   requestsPerSecond,
+			burst:  float64(burst),
+			tokens: float64(burst),
+			last:   time.Now(),
+		}
+	}
+}
+
+// rateLimiter is a token bucket. A nil *rateLimiter imposes no limit.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64 // bucket capacity
+	tokens float64 // may go negative to queue up waiting callers
+	last   time.Time
+}
+
+// wait blocks until a token is available or ctx is done.
+// Each caller reserves its token up front, so waiters are served in order.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Give the reserved token back to the other waiters
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return fmt.Errorf("rate limit: %w", ctx.Err())
+	}
+}
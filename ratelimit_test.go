@@ -0,0 +1,61 @@
+package weathersync
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestRateLimitSharedAcrossGoroutines tests that concurrent callers are
+// throttled collectively by one Client
+func TestRateLimitSharedAcrossGoroutines(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"current": {"temperature_2m": 15.3}}`))
+	}))
+	defer server.Close()
+
+	// 2 immediate requests, then one every 50ms
+	client := New(WithAPIURL(server.URL), WithRateLimit(20, 2))
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			location := Location{Latitude: float64(index)}
+			if _, err := client.FetchWeather(context.Background(), location); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// 4 requests beyond the burst need at least 4 * 50ms
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Errorf("Expected throttling to take at least 180ms, took %v", elapsed)
+	}
+}
+
+// TestRateLimitContextCancel tests that waiting for a token respects ctx
+func TestRateLimitContextCancel(t *testing.T) {
+	client := New(WithRateLimit(0.1, 1))
+
+	// Drain the bucket
+	if err := client.limiter.wait(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := client.limiter.wait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package weathersync
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// retryPolicy controls how failed requests are retried.
+// The zero value disables retries.
+type retryPolicy struct {
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+}
+
+// WithRetry enables automatic retries of failed requests.
+// Connection errors and HTTP 429, 502, 503 and 504 responses are retried up
+// to maxRetries times with exponential backoff and jitter, starting at
+// baseDelay and capped at maxDelay. A Retry-After header on 429 and 503
+// responses takes precedence over the computed delay. Retries never wait
+// past the context deadline.
+// Default is no retries.
+func WithRetry(maxRetries int, baseDelay, maxDelay time.Duration) Option {
+	return func(c *Client) {
+		c.retry = retryPolicy{
+			maxRetries: maxRetries,
+			baseDelay:  baseDelay,
+			maxDelay:   maxDelay,
+		}
+	}
+}
+
+// backoff returns the delay before the given retry (1 for the first retry):
+// baseDelay doubled per attempt, capped at maxDelay, with up to half of it
+// randomised to spread out clients retrying at the same time.
+func (p retryPolicy) backoff(retry int) time.Duration {
+	d := p.baseDelay
+	for i := 1; i < retry && d < p.maxDelay; i++ {
+		d *= 2
+	}
+	if p.maxDelay > 0 && d > p.maxDelay {
+		d = p.maxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+// retryable reports whether a request that failed with err is worth retrying,
+// and the delay requested by the server, if any.
+func retryable(ctx context.Context, err error) (bool, time.Duration) {
+	if ctx.Err() != nil {
+		return false, 0
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Retryable, apiErr.RetryAfter
+	}
+
+	// Transport failures (connection reset, refused, client timeout, ...)
+	// are reported by http.Client.Do as *url.Error, which implements net.Error.
+	var netErr net.Error
+	return errors.As(err, &netErr), 0
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as
+// an HTTP date. It returns zero if the header is absent or invalid.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// fetchJSON performs a GET request against url and decodes the JSON response
+// body into v, retrying retryable failures according to c.retry.
+// It returns the number of attempts made.
+func (c *Client) fetchJSON(ctx context.Context, url string, v interface{}) (int, error) {
+	return c.fetchJSONWithHeader(ctx, url, nil, v)
+}
+
+// fetchJSONWithHeader is like fetchJSON but sends the given extra header.
+func (c *Client) fetchJSONWithHeader(ctx context.Context, url string, header http.Header, v interface{}) (int, error) {
+	for attempt := 1; ; attempt++ {
+		err := c.doJSON(ctx, url, header, v)
+		if err == nil || attempt > c.retry.maxRetries {
+			return attempt, err
+		}
+
+		ok, delay := retryable(ctx, err)
+		if !ok {
+			return attempt, err
+		}
+		if delay == 0 {
+			delay = c.retry.backoff(attempt)
+		}
+
+		// Don't start a wait that cannot finish before the deadline
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return attempt, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return attempt, err
+		case <-timer.C:
+		}
+	}
+}
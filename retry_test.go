@@ -0,0 +1,126 @@
+package weathersync
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestFetchWeatherRetrySuccess tests that transient failures are retried
+// and the attempt count is recorded
+func TestFetchWeatherRetrySuccess(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"current": {"temperature_2m": 15.3}}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL), WithRetry(3, time.Millisecond, 10*time.Millisecond))
+
+	data, err := client.FetchWeather(context.Background(), Location{Name: "Berlin"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if data.Attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", data.Attempts)
+	}
+}
+
+// TestFetchWeatherRetryNonRetryable tests that client errors are not retried
+func TestFetchWeatherRetryNonRetryable(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL), WithRetry(3, time.Millisecond, 10*time.Millisecond))
+
+	if _, err := client.FetchWeather(context.Background(), Location{}); err == nil {
+		t.Fatal("Expected error for API status 400, got nil")
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Expected 1 call, got %d", got)
+	}
+}
+
+// TestFetchWeatherRetryAfterDeadline tests that a Retry-After delay beyond the
+// context deadline returns the error immediately instead of waiting
+func TestFetchWeatherRetryAfterDeadline(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL), WithRetry(3, time.Millisecond, 10*time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := client.FetchWeather(ctx, Location{}); err == nil {
+		t.Fatal("Expected error for API status 429, got nil")
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected immediate return, took %v", elapsed)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Expected 1 call, got %d", got)
+	}
+}
+
+// TestRetryBackoff tests that backoff delays grow and respect the cap
+func TestRetryBackoff(t *testing.T) {
+	p := retryPolicy{maxRetries: 5, baseDelay: 100 * time.Millisecond, maxDelay: 400 * time.Millisecond}
+
+	tests := []struct {
+		retry    int
+		min, max time.Duration
+	}{
+		{retry: 1, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{retry: 2, min: 100 * time.Millisecond, max: 200 * time.Millisecond},
+		{retry: 5, min: 200 * time.Millisecond, max: 400 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		d := p.backoff(tt.retry)
+		if d < tt.min || d > tt.max {
+			t.Errorf("backoff(%d) = %v, want between %v and %v", tt.retry, d, tt.min, tt.max)
+		}
+	}
+}
+
+// TestParseRetryAfter tests both Retry-After header formats
+func TestParseRetryAfter(t *testing.T) {
+	if d := parseRetryAfter("5"); d != 5*time.Second {
+		t.Errorf("Expected 5s, got %v", d)
+	}
+
+	future := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if d := parseRetryAfter(future); d <= 0 || d > time.Minute {
+		t.Errorf("Expected delay up to 1m, got %v", d)
+	}
+
+	if d := parseRetryAfter("soon"); d != 0 {
+		t.Errorf("Expected 0 for invalid header, got %v", d)
+	}
+}
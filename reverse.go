@@ -0,0 +1,194 @@
+package weathersync
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ReverseGeocoder resolves coordinates to a nearby named place.
+// Use WithReverseGeocoder to plug in a custom implementation.
+type ReverseGeocoder interface {
+	// ReverseGeocode returns the place nearest to the given coordinates,
+	// or nil if no place is close enough.
+	ReverseGeocode(ctx context.Context, latitude, longitude float64) (*Place, error)
+}
+
+// WithReverseGeocoder sets the backend used to name locations without a
+// Name (see NameLocation). Default is NewOfflineGeocoder(0).
+func WithReverseGeocoder(g ReverseGeocoder) Option {
+	return func(c *Client) {
+		c.reverseGeocoder = g
+	}
+}
+
+// defaultMaxDistance is the default search radius of OfflineGeocoder in km.
+const defaultMaxDistance = 50
+
+// OfflineGeocoder is a ReverseGeocoder looking up the nearest city in an
+// embedded extract of GeoNames data (CC BY 4.0) covering about 260 major
+// cities worldwide. It makes no network requests.
+type OfflineGeocoder struct {
+	maxDistance float64
+}
+
+// NewOfflineGeocoder creates an OfflineGeocoder that only reports cities
+// within maxDistance kilometers (default 50 if zero or negative). Since only
+// about 260 major cities are covered, most sites farther than that from one
+// of them stay unnamed; plug in an online ReverseGeocoder for full coverage.
+func NewOfflineGeocoder(maxDistance float64) *OfflineGeocoder {
+	if maxDistance <= 0 {
+		maxDistance = defaultMaxDistance
+	}
+	return &OfflineGeocoder{maxDistance: maxDistance}
+}
+
+// ReverseGeocode implements ReverseGeocoder.
+func (g *OfflineGeocoder) ReverseGeocode(ctx context.Context, latitude, longitude float64) (*Place, error) {
+	cities, err := loadCities()
+	if err != nil {
+		return nil, err
+	}
+
+	var nearest *Place
+	best := g.maxDistance
+	for i := range cities {
+		d := distanceKm(latitude, longitude, cities[i].Latitude, cities[i].Longitude)
+		if d <= best {
+			nearest, best = &cities[i], d
+		}
+	}
+
+	if nearest == nil {
+		return nil, nil
+	}
+	place := *nearest
+	return &place, nil
+}
+
+// citiesTSV is the embedded city dataset, an extract of GeoNames
+// (https://www.geonames.org/, CC BY 4.0): one city per line with the
+// tab-separated columns name, latitude, longitude, country code, country,
+// admin1 region, population and timezone. Lines starting with # are comments.
+//
+//go:embed data/cities.tsv
+var citiesTSV []byte
+
+var (
+	citiesOnce sync.Once
+	cities     []Place
+	citiesErr  error
+)
+
+// loadCities parses citiesTSV on first use.
+func loadCities() ([]Place, error) {
+	citiesOnce.Do(func() {
+		cities, citiesErr = parseCities(citiesTSV)
+	})
+	return cities, citiesErr
+}
+
+// parseCities parses a city dataset in the format of citiesTSV.
+func parseCities(data []byte) ([]Place, error) {
+	var places []Place
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Split(text, "\t")
+		if len(fields) != 8 {
+			return nil, fmt.Errorf("cities line %d: want 8 fields, got %d", line, len(fields))
+		}
+
+		lat, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("cities line %d: latitude: %w", line, err)
+		}
+		lon, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("cities line %d: longitude: %w", line, err)
+		}
+		population, err := strconv.Atoi(fields[6])
+		if err != nil {
+			return nil, fmt.Errorf("cities line %d: population: %w", line, err)
+		}
+
+		places = append(places, Place{
+			Location: Location{
+				Name:      fields[0],
+				Latitude:  lat,
+				Longitude: lon,
+				Timezone:  fields[7],
+				Country:   fields[4],
+				Region:    fields[5],
+			},
+			CountryCode: fields[3],
+			Population:  population,
+		})
+	}
+
+	return places, scanner.Err()
+}
+
+// distanceKm returns the great-circle distance between two points in km.
+func distanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadius = 6371.0
+
+	phi1 := lat1 * math.Pi / 180
+	phi2 := lat2 * math.Pi / 180
+	dPhi := (lat2 - lat1) * math.Pi / 180
+	dLambda := (lon2 - lon1) * math.Pi / 180
+
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) +
+		math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// NameLocation returns location with its Name, Country and Region filled in
+// from the nearest place found by the client's ReverseGeocoder. Fields that
+// are already set are kept. If no place is close enough, location is
+// returned unchanged.
+//
+// FetchWeather and FetchMultiple do this automatically for locations
+// without a Name, ignoring lookup errors.
+func (c *Client) NameLocation(ctx context.Context, location Location) (Location, error) {
+	place, err := c.reverseGeocoder.ReverseGeocode(ctx, location.Latitude, location.Longitude)
+	if err != nil {
+		return location, fmt.Errorf("reverse geocode: %w", err)
+	}
+	if place == nil {
+		return location, nil
+	}
+
+	if location.Name == "" {
+		location.Name = place.Name
+	}
+	if location.Country == "" {
+		location.Country = place.Country
+	}
+	if location.Region == "" {
+		location.Region = place.Region
+	}
+	return location, nil
+}
+
+// autoName fills in a missing Name as described on NameLocation.
+func (c *Client) autoName(ctx context.Context, location Location) Location {
+	if location.Name != "" {
+		return location
+	}
+	if named, err := c.NameLocation(ctx, location); err == nil {
+		return named
+	}
+	return location
+}
@@ -0,0 +1,101 @@
+package weathersync
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// fixedGeocoder is a ReverseGeocoder returning a canned place for tests
+type fixedGeocoder struct {
+	place *Place
+	err   error
+}
+
+func (g fixedGeocoder) ReverseGeocode(ctx context.Context, latitude, longitude float64) (*Place, error) {
+	return g.place, g.err
+}
+
+// TestOfflineGeocoder tests nearest-city lookup in the embedded dataset
+func TestOfflineGeocoder(t *testing.T) {
+	g := NewOfflineGeocoder(0)
+
+	// Potsdam is about 27 km from Berlin
+	place, err := g.ReverseGeocode(context.Background(), 52.39, 13.06)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if place == nil || place.Name != "Berlin" || place.Country != "Germany" || place.CountryCode != "DE" {
+		t.Fatalf("Expected Berlin, got %+v", place)
+	}
+
+	// Middle of the Atlantic
+	place, err = g.ReverseGeocode(context.Background(), 30, -40)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if place != nil {
+		t.Errorf("Expected no place, got %+v", place)
+	}
+}
+
+// TestEmbeddedCities tests that the embedded dataset parses
+func TestEmbeddedCities(t *testing.T) {
+	cities, err := loadCities()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(cities) < 100 {
+		t.Errorf("Expected at least 100 cities, got %d", len(cities))
+	}
+}
+
+// TestNameLocation tests that only missing fields are filled in
+func TestNameLocation(t *testing.T) {
+	place := &Place{Location: Location{Name: "Springfield", Country: "United States", Region: "Illinois"}}
+	client := New(WithReverseGeocoder(fixedGeocoder{place: place}))
+
+	named, err := client.NameLocation(context.Background(), Location{Latitude: 39.8, Longitude: -89.6, Region: "IL"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if named.Name != "Springfield" || named.Country != "United States" || named.Region != "IL" {
+		t.Errorf("Unexpected location %+v", named)
+	}
+	if named.Latitude != 39.8 {
+		t.Errorf("Expected coordinates to be kept, got %f", named.Latitude)
+	}
+}
+
+// TestFetchMultipleNamesLocations tests that nameless locations are named in
+// results, including failed ones, and that lookup errors are ignored
+func TestFetchMultipleNamesLocations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL), WithRetry(0, 0, 0))
+
+	results := client.FetchMultiple(context.Background(), []Location{
+		{Latitude: 48.86, Longitude: 2.35},
+		{Name: "Site 7", Latitude: 48.86, Longitude: 2.35},
+	})
+
+	if results[0].Location.Name != "Paris" || results[0].Location.Region != "Île-de-France" {
+		t.Errorf("Expected location named Paris, got %+v", results[0].Location)
+	}
+	if results[1].Location.Name != "Site 7" || results[1].Location.Country != "" {
+		t.Errorf("Expected named location to be kept, got %+v", results[1].Location)
+	}
+
+	failing := New(WithAPIURL(server.URL), WithRetry(0, 0, 0),
+		WithReverseGeocoder(fixedGeocoder{err: errors.New("lookup failed")}))
+	results = failing.FetchMultiple(context.Background(), []Location{{Latitude: 1}})
+	if results[0].Location.Name != "" || results[0].Location.Latitude != 1 {
+		t.Errorf("Expected unchanged location, got %+v", results[0].Location)
+	}
+}
@@ -12,37 +12,61 @@ type Location struct {
 
 	// Longitude is the geographic longitude in decimal degrees
 	Longitude float64
+
+	// Timezone is the IANA timezone name of the location (e.g., "Europe/Berlin").
+	// It is filled in from the API response when WithTimezone is "auto" (the
+	// default) and need not be set on input.
+	Timezone string
+
+	// Country is the name of the country the location is in, if known
+	// (see Geocode)
+	Country string
+
+	// Region is the first-level administrative region the location is in,
+	// such as a state or province, if known (see Geocode)
+	Region string
+
+	// Elevation is the height above mean sea level in meters. When set,
+	// FetchWeather passes it to Open-Meteo, which downscales temperatures to
+	// this height instead of the terrain height of its 90 m elevation model.
+	// Zero means unknown (see Client.Elevation).
+	Elevation float64
 }
 
 // WeatherData contains comprehensive weather information for a specific location.
-// All fields are populated by the Open-Meteo API.
+// Measurements the provider did not report, or that were not requested
+// (see WithVariables), are NaN; WeatherCode is then 0.
 type WeatherData struct {
 	// Location is the geographic location this data applies to
 	Location Location
 
-	// Temperature is the current temperature in Celsius
+	// Temperature is the current temperature in Celsius, or in the
+	// temperature unit set with WithUnits
 	Temperature float64
 
-	// ApparentTemperature is how the temperature "feels" in Celsius
+	// ApparentTemperature is how the temperature "feels", in the same unit
+	// as Temperature
 	ApparentTemperature float64
 
 	// Humidity is the relative humidity as a percentage (0-100)
 	Humidity float64
 
-	// Precipitation is the rainfall in millimeters
+	// Precipitation is the rainfall in millimeters, or in the precipitation
+	// unit set with WithUnits
 	Precipitation float64
 
 	// WeatherCode is the WMO weather interpretation code
 	// See https://open-meteo.com/en/docs for code descriptions
 	WeatherCode int
 
-	// WindSpeed is the wind speed at 10 meters height in km/h
+	// WindSpeed is the wind speed at 10 meters height in km/h, or in the
+	// wind speed unit set with WithUnits
 	WindSpeed float64
 
 	// WindDirection is the wind direction at 10 meters height in degrees (0-360)
 	WindDirection float64
 
-	// WindGusts is the maximum wind gust speed in km/h
+	// WindGusts is the maximum wind gust speed, in the same unit as WindSpeed
 	WindGusts float64
 
 	// CloudCover is the total cloud coverage as a percentage (0-100)
@@ -54,12 +78,57 @@ type WeatherData struct {
 	// Pressure is the atmospheric pressure at mean sea level in hPa
 	Pressure float64
 
+	// Units are the units of Temperature, ApparentTemperature, WindSpeed,
+	// WindGusts and Precipitation (see WithUnits)
+	Units Units
+
 	// FetchDuration is the time it took to fetch this data
 	FetchDuration time.Duration
 
 	// Timestamp is when this data was fetched
 	Timestamp time.Time
 
+	// ObservationTime is the time the values refer to, in the timezone set
+	// with WithTimezone (by default the location's own). Compare it with
+	// Timestamp to tell stale data from fresh data. It is zero if the
+	// provider does not report it.
+	ObservationTime time.Time
+
+	// Interval is the length of the model time step the values describe,
+	// e.g. 15 minutes; precipitation is summed over it. It is zero if the
+	// provider does not report it.
+	Interval time.Duration
+
+	// Attempts is the number of HTTP requests made to fetch this data,
+	// including retries (see WithRetry). It is zero for cached data.
+	Attempts int
+
+	// Source is the name of the provider that served this data,
+	// e.g. "open-meteo" (see Provider and NewFailover)
+	Source string
+
+	// Spread reports, per merged field name (e.g. "Temperature"), how far
+	// the answers of an Ensemble provider were apart: the difference between
+	// the highest and lowest value, or the largest angular deviation from the
+	// mean for "WindDirection". Fields no provider reported are not included.
+	// It is nil for single-provider data.
+	Spread map[string]float64
+
+	// Extra holds the requested variables without a dedicated field
+	// (see WithVariables), keyed by Open-Meteo variable name, e.g. "uv_index".
+	// Variables missing from the response are not included.
+	// It is nil if no such variables were requested.
+	Extra map[string]float64
+
+	// Models holds one result per weather model when several Open-Meteo
+	// models were requested (see WithModels), keyed by model name.
+	// It is nil otherwise.
+	Models map[string]WeatherData
+
+	// Cached reports whether this data was served from the client's cache
+	// (see WithCache). Timestamp then still refers to the original fetch.
+	Cached bool
+
 	// Error contains any error that occurred during fetching
 	// If nil, the fetch was successful
 	Error error
@@ -0,0 +1,187 @@
+package weathersync
+
+// TemperatureUnit is a unit for temperatures, named as in the Open-Meteo
+// temperature_unit parameter.
+type TemperatureUnit string
+
+// WindSpeedUnit is a unit for wind speeds, named as in the Open-Meteo
+// wind_speed_unit parameter.
+type WindSpeedUnit string
+
+// PrecipitationUnit is a unit for precipitation amounts, named as in the
+// Open-Meteo precipitation_unit parameter.
+type PrecipitationUnit string
+
+// Supported units.
+const (
+	Celsius    TemperatureUnit = "celsius"
+	Fahrenheit TemperatureUnit = "fahrenheit"
+
+	KilometersPerHour WindSpeedUnit = "kmh"
+	MetersPerSecond   WindSpeedUnit = "ms"
+	MilesPerHour      WindSpeedUnit = "mph"
+	Knots             WindSpeedUnit = "kn"
+
+	Millimeters PrecipitationUnit = "mm"
+	Inches      PrecipitationUnit = "inch"
+)
+
+// Units is a combination of units for weather values. Empty fields use the
+// metric unit. Other values (humidity, pressure, visibility, ...) are always
+// reported in the units documented on WeatherData.
+type Units struct {
+	// Temperature is used for temperatures and apparent temperatures
+	Temperature TemperatureUnit
+
+	// WindSpeed is used for wind speeds and gusts
+	WindSpeed WindSpeedUnit
+
+	// Precipitation is used for precipitation amounts
+	Precipitation PrecipitationUnit
+}
+
+// Predefined unit systems.
+var (
+	// Metric uses °C, km/h and mm. It is the default.
+	Metric = Units{Temperature: Celsius, WindSpeed: KilometersPerHour, Precipitation: Millimeters}
+
+	// Imperial uses °F, mph and inches.
+	Imperial = Units{Temperature: Fahrenheit, WindSpeed: MilesPerHour, Precipitation: Inches}
+)
+
+// WithUnits sets the units of temperatures, wind speeds and precipitation
+// returned by FetchWeather, FetchMultiple, forecasts and history, e.g.
+// Imperial or Units{WindSpeed: Knots} for metric values with wind in knots.
+// Values from providers reporting other units are converted.
+// Default is Metric.
+func WithUnits(units Units) Option {
+	return func(c *Client) {
+		c.units = units.normalized()
+	}
+}
+
+// normalized returns u with empty fields set to the metric unit.
+func (u Units) normalized() Units {
+	if u.Temperature == "" {
+		u.Temperature = Celsius
+	}
+	if u.WindSpeed == "" {
+		u.WindSpeed = KilometersPerHour
+	}
+	if u.Precipitation == "" {
+		u.Precipitation = Millimeters
+	}
+	return u
+}
+
+// params returns the Open-Meteo query string fragment selecting u. Metric
+// units are the API default and are omitted.
+func (u Units) params() string {
+	u = u.normalized()
+
+	var params string
+	if u.Temperature != Celsius {
+		params += "&temperature_unit=" + string(u.Temperature)
+	}
+	if u.WindSpeed != KilometersPerHour {
+		params += "&wind_speed_unit=" + string(u.WindSpeed)
+	}
+	if u.Precipitation != Millimeters {
+		params += "&precipitation_unit=" + string(u.Precipitation)
+	}
+	return params
+}
+
+// Symbol returns the display symbol of the unit, e.g. "°F".
+func (u TemperatureUnit) Symbol() string {
+	if u == Fahrenheit {
+		return "°F"
+	}
+	return "°C"
+}
+
+// Symbol returns the display symbol of the unit, e.g. "mph".
+func (u WindSpeedUnit) Symbol() string {
+	switch u {
+	case MetersPerSecond:
+		return "m/s"
+	case MilesPerHour:
+		return "mph"
+	case Knots:
+		return "kn"
+	}
+	return "km/h"
+}
+
+// Symbol returns the display symbol of the unit, e.g. "in".
+func (u PrecipitationUnit) Symbol() string {
+	if u == Inches {
+		return "in"
+	}
+	return "mm"
+}
+
+// kmhFactor returns the number of km/h in one unit of u.
+func (u WindSpeedUnit) kmhFactor() float64 {
+	switch u {
+	case MetersPerSecond:
+		return 3.6
+	case MilesPerHour:
+		return 1.609344
+	case Knots:
+		return 1.852
+	}
+	return 1
+}
+
+// mmFactor returns the number of millimeters in one unit of u.
+func (u PrecipitationUnit) mmFactor() float64 {
+	if u == Inches {
+		return 25.4
+	}
+	return 1
+}
+
+// convertUnits converts the values of data, including per-model results and
+// Ensemble spreads, from data.Units to units and records the new units.
+// Extra variables are left unchanged.
+func (data *WeatherData) convertUnits(units Units) {
+	from, to := data.Units.normalized(), units.normalized()
+	data.Units = to
+	if from == to {
+		return
+	}
+
+	// Differences (spreads) only scale; absolute temperatures also shift
+	tempScale, tempOffset := 1.0, 0.0
+	switch {
+	case from.Temperature == Celsius && to.Temperature == Fahrenheit:
+		tempScale, tempOffset = 9.0/5, 32
+	case from.Temperature == Fahrenheit && to.Temperature == Celsius:
+		tempScale, tempOffset = 5.0/9, -32*5.0/9
+	}
+	windScale := from.WindSpeed.kmhFactor() / to.WindSpeed.kmhFactor()
+	precipScale := from.Precipitation.mmFactor() / to.Precipitation.mmFactor()
+
+	data.Temperature = data.Temperature*tempScale + tempOffset
+	data.ApparentTemperature = data.ApparentTemperature*tempScale + tempOffset
+	data.WindSpeed *= windScale
+	data.WindGusts *= windScale
+	data.Precipitation *= precipScale
+
+	for name, spread := range data.Spread {
+		switch name {
+		case "Temperature", "ApparentTemperature":
+			data.Spread[name] = spread * tempScale
+		case "WindSpeed", "WindGusts":
+			data.Spread[name] = spread * windScale
+		case "Precipitation":
+			data.Spread[name] = spread * precipScale
+		}
+	}
+
+	for model, m := range data.Models {
+		m.convertUnits(to)
+		data.Models[model] = m
+	}
+}
@@ -0,0 +1,112 @@
+package weathersync
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestFetchWeatherImperialUnits tests that units are passed to the API and
+// recorded on the result
+func TestFetchWeatherImperialUnits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("temperature_unit") != "fahrenheit" || q.Get("wind_speed_unit") != "mph" || q.Get("precipitation_unit") != "inch" {
+			t.Errorf("Unexpected unit parameters %q", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"current": {"temperature_2m": 59.5, "wind_speed_10m": 7.4}}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL), WithUnits(Imperial))
+
+	data, err := client.FetchWeather(context.Background(), Location{Name: "Denver"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Values from Open-Meteo are already in the requested units
+	if data.Temperature != 59.5 || data.WindSpeed != 7.4 {
+		t.Errorf("Expected 59.5°F and 7.4 mph, got %f/%f", data.Temperature, data.WindSpeed)
+	}
+	if data.Units != Imperial {
+		t.Errorf("Expected imperial units, got %+v", data.Units)
+	}
+}
+
+// TestFetchWeatherMetricOmitsUnits tests that the default units add no
+// parameters to the request
+func TestFetchWeatherMetricOmitsUnits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query(); q.Has("temperature_unit") || q.Has("wind_speed_unit") || q.Has("precipitation_unit") {
+			t.Errorf("Unexpected unit parameters %q", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"current": {"temperature_2m": 15.0}}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL))
+
+	data, err := client.FetchWeather(context.Background(), Location{Name: "Berlin"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if data.Units != Metric {
+		t.Errorf("Expected metric units, got %+v", data.Units)
+	}
+}
+
+// TestFetchWeatherConvertsProviderUnits tests that metric values from other
+// providers are converted to the configured units
+func TestFetchWeatherConvertsProviderUnits(t *testing.T) {
+	client := New(
+		WithProvider(&stubProvider{name: "stub", temp: 20}),
+		WithUnits(Units{Temperature: Fahrenheit, WindSpeed: Knots}),
+	)
+
+	data, err := client.FetchWeather(context.Background(), Location{Name: "A"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if data.Temperature != 68 {
+		t.Errorf("Expected 68°F, got %f", data.Temperature)
+	}
+
+	want := Units{Temperature: Fahrenheit, WindSpeed: Knots, Precipitation: Millimeters}
+	if data.Units != want {
+		t.Errorf("Expected units %+v, got %+v", want, data.Units)
+	}
+}
+
+// TestConvertUnits tests conversion of values and spreads in both directions
+func TestConvertUnits(t *testing.T) {
+	data := WeatherData{
+		Temperature:   -40,
+		WindSpeed:     18.52,
+		Precipitation: 25.4,
+		Spread:        map[string]float64{"Temperature": 5, "Humidity": 3},
+	}
+
+	data.convertUnits(Units{Temperature: Fahrenheit, WindSpeed: Knots, Precipitation: Inches})
+
+	approx := func(got, want float64) bool { return math.Abs(got-want) < 1e-9 }
+	if !approx(data.Temperature, -40) || !approx(data.WindSpeed, 10) || !approx(data.Precipitation, 1) {
+		t.Errorf("Unexpected converted values %f/%f/%f", data.Temperature, data.WindSpeed, data.Precipitation)
+	}
+	if !approx(data.Spread["Temperature"], 9) || data.Spread["Humidity"] != 3 {
+		t.Errorf("Unexpected converted spreads %v", data.Spread)
+	}
+
+	data.Temperature = 212
+	data.convertUnits(Metric)
+	if !approx(data.Temperature, 100) || !approx(data.WindSpeed, 18.52) {
+		t.Errorf("Unexpected values after converting back %f/%f", data.Temperature, data.WindSpeed)
+	}
+}
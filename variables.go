@@ -0,0 +1,112 @@
+package weathersync
+
+import "strings"
+
+// Variable is an Open-Meteo current weather variable, such as
+// "temperature_2m" or "uv_index". Any variable listed in the Open-Meteo
+// documentation can be used by converting its name, e.g.
+// Variable("soil_moisture_0_to_1cm").
+type Variable string
+
+// Variables mapped to the fields of WeatherData. Together they are the
+// default selection.
+const (
+	Temperature         Variable = "temperature_2m"
+	ApparentTemperature Variable = "apparent_temperature"
+	RelativeHumidity    Variable = "relative_humidity_2m"
+	Precipitation       Variable = "precipitation"
+	WeatherCode         Variable = "weather_code"
+	WindSpeed           Variable = "wind_speed_10m"
+	WindDirection       Variable = "wind_direction_10m"
+	WindGusts           Variable = "wind_gusts_10m"
+	CloudCover          Variable = "cloud_cover"
+	Visibility          Variable = "visibility"
+	PressureMSL         Variable = "pressure_msl"
+)
+
+// Commonly used variables without a dedicated WeatherData field. Their
+// values are reported in WeatherData.Extra.
+const (
+	Rain             Variable = "rain"
+	Showers          Variable = "showers"
+	Snowfall         Variable = "snowfall"
+	SnowDepth        Variable = "snow_depth"
+	IsDay            Variable = "is_day"
+	UVIndex          Variable = "uv_index"
+	CAPE             Variable = "cape"
+	DewPoint         Variable = "dew_point_2m"
+	SurfacePressure  Variable = "surface_pressure"
+	SoilTemperature0 Variable = "soil_temperature_0cm"
+)
+
+// DefaultVariables returns the variables requested when no selection is
+// made with WithVariables: those mapped to the fields of WeatherData.
+func DefaultVariables() []Variable {
+	return []Variable{
+		Temperature, ApparentTemperature, RelativeHumidity, Precipitation,
+		WeatherCode, WindSpeed, WindDirection, WindGusts, CloudCover,
+		Visibility, PressureMSL,
+	}
+}
+
+// WithVariables selects the current weather variables requested from
+// Open-Meteo. Fields of WeatherData whose variable is not requested are
+// NaN (WeatherCode 0); requested variables without a field are reported in
+// WeatherData.Extra. Default is DefaultVariables. Use Variables to override
+// it for a single call. Other providers ignore this setting.
+func WithVariables(variables ...Variable) Option {
+	return func(c *Client) {
+		c.variables = variables
+	}
+}
+
+// Variables requests the given variables for this call only, overriding
+// WithVariables.
+func Variables(variables ...Variable) FetchOption {
+	return func(q *fetchQuery) {
+		q.variables = variables
+	}
+}
+
+// joinVariables returns variables as a comma separated list.
+func joinVariables(variables []Variable) string {
+	names := make([]string, len(variables))
+	for i, v := range variables {
+		names[i] = string(v)
+	}
+	return strings.Join(names, ",")
+}
+
+// setVariable stores value in the field of data mapped to v, or in
+// data.Extra if v has no field.
+func (data *WeatherData) setVariable(v Variable, value float64) {
+	switch v {
+	case Temperature:
+		data.Temperature = value
+	case ApparentTemperature:
+		data.ApparentTemperature = value
+	case RelativeHumidity:
+		data.Humidity = value
+	case Precipitation:
+		data.Precipitation = value
+	case WeatherCode:
+		data.WeatherCode = int(value)
+	case WindSpeed:
+		data.WindSpeed = value
+	case WindDirection:
+		data.WindDirection = value
+	case WindGusts:
+		data.WindGusts = value
+	case CloudCover:
+		data.CloudCover = value
+	case Visibility:
+		data.Visibility = value
+	case PressureMSL:
+		data.Pressure = value
+	default:
+		if data.Extra == nil {
+			data.Extra = make(map[string]float64)
+		}
+		data.Extra[string(v)] = value
+	}
+}
@@ -0,0 +1,84 @@
+package weathersync
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestFetchWeatherVariables tests that only the selected variables are
+// requested and that variables without a field end up in Extra
+func TestFetchWeatherVariables(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if current := r.URL.Query().Get("current"); current != "temperature_2m,uv_index,is_day" {
+			t.Errorf("Expected current=temperature_2m,uv_index,is_day, got %q", current)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"current": {
+				"time": "2024-06-01T12:00",
+				"temperature_2m": 18.2,
+				"uv_index": 6.5,
+				"is_day": 1,
+				"wind_speed_10m": 12.0
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL), WithVariables(Temperature, UVIndex, IsDay))
+
+	data, err := client.FetchWeather(context.Background(), Location{Name: "Berlin"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if data.Temperature != 18.2 {
+		t.Errorf("Expected temperature 18.2, got %f", data.Temperature)
+	}
+
+	// Unrequested variables are ignored even if present
+	if !math.IsNaN(data.WindSpeed) {
+		t.Errorf("Expected unrequested wind speed to be NaN, got %f", data.WindSpeed)
+	}
+
+	if len(data.Extra) != 2 || data.Extra["uv_index"] != 6.5 || data.Extra["is_day"] != 1 {
+		t.Errorf("Unexpected extra variables %v", data.Extra)
+	}
+}
+
+// TestFetchWeatherDefaultVariables tests the default selection and the
+// per-call override
+func TestFetchWeatherDefaultVariables(t *testing.T) {
+	var current string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current = r.URL.Query().Get("current")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"current": {"snowfall": 0.7}}`))
+	}))
+	defer server.Close()
+
+	client := New(WithAPIURL(server.URL))
+
+	if _, err := client.FetchWeather(context.Background(), Location{Name: "A"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if current != joinVariables(DefaultVariables()) {
+		t.Errorf("Expected default variables, got %q", current)
+	}
+
+	data, err := client.FetchWeather(context.Background(), Location{Name: "B"}, Variables(Snowfall))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if current != "snowfall" {
+		t.Errorf("Expected current=snowfall, got %q", current)
+	}
+	if data.Extra["snowfall"] != 0.7 {
+		t.Errorf("Expected snowfall 0.7, got %v", data.Extra)
+	}
+}